@@ -0,0 +1,29 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// UseBLAS sets the BLAS float64 implementation used by subsequent real
+// matrix operations. It is a convenience wrapper around blas64.Use, so the
+// setting is process-wide and also affects every other package calling
+// through blas64. UseBLAS must not be called concurrently with any matrix
+// operation. The default implementation is
+// gonum.org/v1/gonum/blas/gonum.Implementation.
+//
+// Some operations use internal assembly kernels or LAPACK routines directly
+// and are not affected by the BLAS implementation.
+func UseBLAS(impl blas.Float64) {
+	blas64.Use(impl)
+}
+
+// BLASImplementation returns the BLAS float64 implementation currently used
+// by real matrix operations. It is equivalent to blas64.Implementation.
+func BLASImplementation() blas.Float64 {
+	return blas64.Implementation()
+}
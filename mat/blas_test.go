@@ -0,0 +1,48 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+func TestUseBLAS(t *testing.T) {
+	// This test does not call t.Parallel since it
+	// changes the process-wide BLAS implementation.
+	impl := BLASImplementation()
+	if impl != blas64.Implementation() {
+		t.Fatalf("BLASImplementation does not match blas64: got %T want %T", impl, blas64.Implementation())
+	}
+	defer blas64.Use(impl)
+
+	counter := &countingBLAS{Float64: impl}
+	UseBLAS(counter)
+	if BLASImplementation() != counter {
+		t.Errorf("unexpected implementation after UseBLAS: got %T", BLASImplementation())
+	}
+	var c Dense
+	c.Mul(NewDense(2, 2, []float64{1, 2, 3, 4}), NewDense(2, 2, []float64{5, 6, 7, 8}))
+	if counter.dgemm == 0 {
+		t.Error("matrix multiplication did not use the selected implementation")
+	}
+	want := NewDense(2, 2, []float64{19, 22, 43, 50})
+	if !Equal(&c, want) {
+		t.Errorf("unexpected product:\ngot:\n%v\nwant:\n%v", Formatted(&c), Formatted(want))
+	}
+}
+
+// countingBLAS is a BLAS implementation that counts calls to Dgemm.
+type countingBLAS struct {
+	blas.Float64
+	dgemm int
+}
+
+func (b *countingBLAS) Dgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, bm []float64, ldb int, beta float64, c []float64, ldc int) {
+	b.dgemm++
+	b.Float64.Dgemm(tA, tB, m, n, k, alpha, a, lda, bm, ldb, beta, c, ldc)
+}
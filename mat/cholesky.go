@@ -145,6 +145,22 @@ func (c *Cholesky) Factorize(a Symmetric) (ok bool) {
 	return ok
 }
 
+// IsPosDef returns whether the symmetric matrix a is positive definite. The
+// test attempts a Cholesky factorization of a copy of a in a workspace, so a
+// is not modified and no factor is retained. IsPosDef returns false, rather
+// than panicking, if a is not positive definite or contains NaN elements.
+func IsPosDef(a Symmetric) bool {
+	n := a.Symmetric()
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	w := getWorkspaceSym(n, false)
+	defer putWorkspaceSym(w)
+	w.CopySym(a)
+	_, ok := lapack64.Potrf(w.mat)
+	return ok
+}
+
 // Reset resets the factorization so that it can be reused as the receiver of a
 // dimensionally restricted operation.
 func (c *Cholesky) Reset() {
@@ -287,7 +303,9 @@ func (c *Cholesky) SolveVecTo(dst *VecDense, b Vector) error {
 		if dst != b {
 			dst.CopyVec(b)
 		}
-		lapack64.Potrs(c.chol.mat, dst.asGeneral())
+		// A = Uᵀ * U, so solve Uᵀ * y = b and then U * x = y.
+		blas64.Trsv(blas.Trans, c.chol.mat, dst.mat)
+		blas64.Trsv(blas.NoTrans, c.chol.mat, dst.mat)
 		if c.cond > ConditionTolerance {
 			return Condition(c.cond)
 		}
@@ -582,15 +600,13 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x Vector) (ok bool)
 
 	work := getFloats(n, false)
 	defer putFloats(work)
-	var xmat blas64.Vector
 	if rv, ok := x.(RawVectorer); ok {
-		xmat = rv.RawVector()
+		blas64.Copy(rv.RawVector(), blas64.Vector{N: n, Data: work, Inc: 1})
 	} else {
-		var tmp *VecDense
-		tmp.CopyVec(x)
-		xmat = tmp.RawVector()
+		for i := range work {
+			work[i] = x.AtVec(i)
+		}
 	}
-	blas64.Copy(xmat, blas64.Vector{N: n, Data: work, Inc: 1})
 
 	if alpha > 0 {
 		// Compute rank-1 update.
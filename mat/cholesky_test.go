@@ -74,6 +74,47 @@ func TestCholesky(t *testing.T) {
 	}
 }
 
+func TestIsPosDef(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a    Symmetric
+		want bool
+	}{
+		{a: NewSymDense(1, []float64{2}), want: true},
+		{a: NewSymDense(1, []float64{0}), want: false},
+		{a: NewSymDense(2, []float64{2, 1, 1, 2}), want: true},
+		{a: NewSymDense(2, []float64{1, 2, 2, 1}), want: false},
+		{a: NewSymDense(2, []float64{1, 1, 1, 1}), want: false},
+		{a: NewDiagDense(3, []float64{1, 2, 3}), want: true},
+		{a: NewDiagDense(3, []float64{1, -2, 3}), want: false},
+		{
+			a: NewSymDense(3, []float64{
+				4, 1, 1,
+				1, 2, 3,
+				1, 3, 6,
+			}),
+			want: true,
+		},
+	} {
+		var orig Dense
+		orig.CloneFrom(test.a)
+		if got := IsPosDef(test.a); got != test.want {
+			t.Errorf("test %d: unexpected result: got %t want %t", i, got, test.want)
+		}
+		if !Equal(test.a, &orig) {
+			t.Errorf("test %d: input modified", i)
+		}
+		var chol Cholesky
+		if ok := chol.Factorize(test.a); ok != test.want {
+			t.Errorf("test %d: result does not match Cholesky.Factorize: got %t want %t", i, test.want, ok)
+		}
+	}
+
+	if IsPosDef(NewSymDense(2, []float64{1, 0, 0, math.NaN()})) {
+		t.Error("unexpected positive definite result for matrix with NaN")
+	}
+}
+
 func TestCholeskyAt(t *testing.T) {
 	t.Parallel()
 	for _, test := range []*SymDense{
@@ -266,6 +307,15 @@ func TestCholeskySolveVecTo(t *testing.T) {
 		if !EqualApprox(&ans, test.b, 1e-12) {
 			t.Error("incorrect Cholesky solve solution product")
 		}
+
+		x.CopyVec(test.b)
+		err = chol.SolveVecTo(&x, &x)
+		if err != nil {
+			t.Errorf("unexpected error from in-place Cholesky solve: %v", err)
+		}
+		if !EqualApprox(&x, test.ans, 1e-12) {
+			t.Error("incorrect in-place Cholesky solve solution")
+		}
 	}
 }
 
@@ -417,6 +467,17 @@ func TestCholeskySymRankOne(t *testing.T) {
 				t.Errorf("n=%v, alpha=%v: mismatch between updated matrix and from Cholesky:\nupdated:\n%v\nfrom Cholesky:\n%v",
 					n, alpha, Formatted(aUpdate), Formatted(&aCompare))
 			}
+
+			// Check that a non-RawVectorer x gives the same result.
+			var cholBasic Cholesky
+			ok = cholBasic.SymRankOne(&chol, alpha, &basicVector{m: xdata})
+			if !ok {
+				t.Errorf("n=%v, alpha=%v: unexpected failure with basic vector", n, alpha)
+				continue
+			}
+			if !EqualApprox(cholBasic.chol, cholUpdate.chol, 1e-14) {
+				t.Errorf("n=%v, alpha=%v: mismatch between basic vector and VecDense update", n, alpha)
+			}
 		}
 	}
 
@@ -0,0 +1,131 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "sort"
+
+// COO is a builder for sparse matrices in coordinate (triplet) format. Entries
+// are added one at a time with Add, in any order, and duplicate entries are
+// summed. This matches the way entries are produced when assembling sparse
+// operators, for example in finite element methods. A COO is converted to a
+// usable form with ToDense or ToCSR.
+//
+// The zero value of COO has no dimensions; use NewCOO to create a COO.
+type COO struct {
+	r, c int
+
+	rows, cols []int
+	data       []float64
+
+	// compressed is true when the triplets are
+	// sorted in row-major order with no duplicates.
+	compressed bool
+}
+
+// NewCOO returns a new r×c COO with no entries.
+// NewCOO panics if either r or c is not positive.
+func NewCOO(r, c int) *COO {
+	if r <= 0 || c <= 0 {
+		if r == 0 || c == 0 {
+			panic(ErrZeroLength)
+		}
+		panic(ErrNegativeDimension)
+	}
+	return &COO{r: r, c: c, compressed: true}
+}
+
+// Dims returns the number of rows and columns of the matrix.
+func (m *COO) Dims() (r, c int) { return m.r, m.c }
+
+// NNZ returns the number of stored entries. Duplicate entries that have not
+// yet been combined by a conversion are counted separately.
+func (m *COO) NNZ() int { return len(m.data) }
+
+// Add adds v to the element at row i and column j. If an entry already
+// exists at (i, j), the values are summed when the matrix is converted.
+// Add panics with ErrIndexOutOfRange if i or j is outside the matrix.
+func (m *COO) Add(i, j int, v float64) {
+	if uint(i) >= uint(m.r) || uint(j) >= uint(m.c) {
+		panic(ErrIndexOutOfRange)
+	}
+	if n := len(m.data); m.compressed && n != 0 {
+		li, lj := m.rows[n-1], m.cols[n-1]
+		m.compressed = li < i || (li == i && lj < j)
+	}
+	m.rows = append(m.rows, i)
+	m.cols = append(m.cols, j)
+	m.data = append(m.data, v)
+}
+
+// ToDense returns a new Dense holding the elements of the receiver.
+func (m *COO) ToDense() *Dense {
+	d := NewDense(m.r, m.c, nil)
+	for k, v := range m.data {
+		d.mat.Data[m.rows[k]*d.mat.Stride+m.cols[k]] += v
+	}
+	return d
+}
+
+// ToCSR returns the elements of the receiver in compressed sparse row format.
+// The column indices and values of the entries in row i are held in
+// ind[indptr[i]:indptr[i+1]] and data[indptr[i]:indptr[i+1]], ordered by
+// increasing column index. Duplicate entries are summed, and the receiver is
+// updated to hold the combined entries. The returned slices do not share
+// backing data with the receiver.
+func (m *COO) ToCSR() (indptr, ind []int, data []float64) {
+	m.compress()
+	indptr = make([]int, m.r+1)
+	for _, i := range m.rows {
+		indptr[i+1]++
+	}
+	for i := 0; i < m.r; i++ {
+		indptr[i+1] += indptr[i]
+	}
+	ind = make([]int, len(m.cols))
+	copy(ind, m.cols)
+	data = make([]float64, len(m.data))
+	copy(data, m.data)
+	return indptr, ind, data
+}
+
+// compress sorts the triplets of the receiver in row-major order and sums
+// duplicate entries.
+func (m *COO) compress() {
+	if m.compressed {
+		return
+	}
+	sort.Stable(cooTriplets{m})
+	n := 0
+	for k := range m.data {
+		if n != 0 && m.rows[k] == m.rows[n-1] && m.cols[k] == m.cols[n-1] {
+			m.data[n-1] += m.data[k]
+			continue
+		}
+		m.rows[n] = m.rows[k]
+		m.cols[n] = m.cols[k]
+		m.data[n] = m.data[k]
+		n++
+	}
+	m.rows = m.rows[:n]
+	m.cols = m.cols[:n]
+	m.data = m.data[:n]
+	m.compressed = true
+}
+
+// cooTriplets sorts the triplets of a COO in row-major order.
+type cooTriplets struct{ *COO }
+
+func (t cooTriplets) Len() int { return len(t.data) }
+func (t cooTriplets) Less(i, j int) bool {
+	if t.rows[i] != t.rows[j] {
+		return t.rows[i] < t.rows[j]
+	}
+	return t.cols[i] < t.cols[j]
+}
+func (t cooTriplets) Swap(i, j int) {
+	t.rows[i], t.rows[j] = t.rows[j], t.rows[i]
+	t.cols[i], t.cols[j] = t.cols[j], t.cols[i]
+	t.data[i], t.data[j] = t.data[j], t.data[i]
+}
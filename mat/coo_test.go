@@ -0,0 +1,137 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestCOO(t *testing.T) {
+	t.Parallel()
+	type entry struct {
+		i, j int
+		v    float64
+	}
+	for _, test := range []struct {
+		r, c    int
+		entries []entry
+
+		want       *Dense
+		wantIndptr []int
+		wantInd    []int
+		wantData   []float64
+	}{
+		{
+			r: 2, c: 3,
+			want:       NewDense(2, 3, nil),
+			wantIndptr: []int{0, 0, 0},
+			wantInd:    []int{},
+			wantData:   []float64{},
+		},
+		{
+			r: 3, c: 3,
+			entries: []entry{
+				{0, 0, 1}, {0, 2, 2}, {1, 1, 3}, {2, 0, 4},
+			},
+			want: NewDense(3, 3, []float64{
+				1, 0, 2,
+				0, 3, 0,
+				4, 0, 0,
+			}),
+			wantIndptr: []int{0, 2, 3, 4},
+			wantInd:    []int{0, 2, 1, 0},
+			wantData:   []float64{1, 2, 3, 4},
+		},
+		{
+			r: 3, c: 4,
+			entries: []entry{
+				{2, 3, 1}, {0, 1, 2}, {2, 3, 5}, {0, 0, -1}, {0, 1, 0.5}, {2, 0, 7},
+			},
+			want: NewDense(3, 4, []float64{
+				-1, 2.5, 0, 0,
+				0, 0, 0, 0,
+				7, 0, 0, 6,
+			}),
+			wantIndptr: []int{0, 2, 2, 4},
+			wantInd:    []int{0, 1, 0, 3},
+			wantData:   []float64{-1, 2.5, 7, 6},
+		},
+	} {
+		m := NewCOO(test.r, test.c)
+		for _, e := range test.entries {
+			m.Add(e.i, e.j, e.v)
+		}
+		if r, c := m.Dims(); r != test.r || c != test.c {
+			t.Errorf("unexpected dimensions: got %d×%d want %d×%d", r, c, test.r, test.c)
+		}
+		d := m.ToDense()
+		if !Equal(d, test.want) {
+			t.Errorf("unexpected dense result:\ngot:\n%v\nwant:\n%v", Formatted(d), Formatted(test.want))
+		}
+		indptr, ind, data := m.ToCSR()
+		if !reflect.DeepEqual(indptr, test.wantIndptr) {
+			t.Errorf("unexpected indptr: got %v want %v", indptr, test.wantIndptr)
+		}
+		if !reflect.DeepEqual(ind, test.wantInd) {
+			t.Errorf("unexpected ind: got %v want %v", ind, test.wantInd)
+		}
+		if !reflect.DeepEqual(data, test.wantData) {
+			t.Errorf("unexpected data: got %v want %v", data, test.wantData)
+		}
+		if m.NNZ() != len(test.wantData) {
+			t.Errorf("unexpected number of entries after conversion: got %d want %d", m.NNZ(), len(test.wantData))
+		}
+		// Conversion must not alter the represented matrix.
+		d = m.ToDense()
+		if !Equal(d, test.want) {
+			t.Errorf("unexpected dense result after CSR conversion:\ngot:\n%v\nwant:\n%v", Formatted(d), Formatted(test.want))
+		}
+	}
+}
+
+func TestCOORandom(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	const r, c = 7, 5
+	m := NewCOO(r, c)
+	want := NewDense(r, c, nil)
+	for k := 0; k < 100; k++ {
+		i, j := rnd.Intn(r), rnd.Intn(c)
+		v := float64(rnd.Intn(10))
+		m.Add(i, j, v)
+		want.Set(i, j, want.At(i, j)+v)
+	}
+	indptr, ind, data := m.ToCSR()
+	got := NewDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for k := indptr[i]; k < indptr[i+1]; k++ {
+			if k > indptr[i] && ind[k-1] >= ind[k] {
+				t.Errorf("column indices not strictly increasing in row %d", i)
+			}
+			got.Set(i, ind[k], data[k])
+		}
+	}
+	if !Equal(got, want) {
+		t.Errorf("unexpected CSR result:\ngot:\n%v\nwant:\n%v", Formatted(got), Formatted(want))
+	}
+}
+
+func TestCOOPanics(t *testing.T) {
+	t.Parallel()
+	m := NewCOO(2, 3)
+	for _, idx := range [][2]int{{-1, 0}, {0, -1}, {2, 0}, {0, 3}} {
+		panicked, message := panics(func() { m.Add(idx[0], idx[1], 1) })
+		if !panicked || message != ErrIndexOutOfRange.Error() {
+			t.Errorf("expected ErrIndexOutOfRange for Add(%d, %d, 1)", idx[0], idx[1])
+		}
+	}
+	panicked, message := panics(func() { NewCOO(0, 2) })
+	if !panicked || message != ErrZeroLength.Error() {
+		t.Errorf("expected ErrZeroLength for NewCOO(0, 2)")
+	}
+}
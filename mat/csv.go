@@ -0,0 +1,143 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// CSVOption is a functional option for CSV encoding and decoding.
+type CSVOption func(*csvConfig)
+
+type csvConfig struct {
+	comma      rune
+	skipHeader bool
+}
+
+// CSVComma sets the field delimiter used for CSV encoding and decoding.
+// The default delimiter is ','.
+func CSVComma(r rune) CSVOption {
+	return func(c *csvConfig) {
+		c.comma = r
+	}
+}
+
+// CSVSkipHeader specifies that the first record of CSV input is a header
+// and should be ignored. CSVSkipHeader has no effect on encoding.
+func CSVSkipHeader() CSVOption {
+	return func(c *csvConfig) {
+		c.skipHeader = true
+	}
+}
+
+func newCSVConfig(options []CSVOption) csvConfig {
+	c := csvConfig{comma: ','}
+	for _, o := range options {
+		o(&c)
+	}
+	return c
+}
+
+// MarshalCSV writes the receiver to w as CSV, one record per row of the
+// matrix. Values are formatted with the minimal precision required to
+// represent them exactly.
+func (m *Dense) MarshalCSV(w io.Writer, options ...CSVOption) error {
+	c := newCSVConfig(options)
+	cw := csv.NewWriter(w)
+	cw.Comma = c.comma
+	r, cols := m.Dims()
+	record := make([]string, cols)
+	for i := 0; i < r; i++ {
+		for j, v := range m.rawRowView(i) {
+			record[j] = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		err := cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// UnmarshalCSV reads a rectangular numeric CSV table from r into the
+// receiver, one row of the matrix per record. It panics if the receiver
+// is a non-empty Dense matrix.
+//
+// UnmarshalCSV returns an error identifying the line of the input holding
+// the offending record if a record has a different number of fields to the
+// first record. If a field cannot be parsed as a float64, the returned error
+// identifies the offending record, counted from one and including a skipped
+// header; blank lines are not counted, so the record number may differ from
+// the line number in the input. If r holds no records, ErrZeroLength is
+// returned.
+func (m *Dense) UnmarshalCSV(r io.Reader, options ...CSVOption) error {
+	if !m.IsEmpty() {
+		panic("mat: unmarshal into non-empty matrix")
+	}
+
+	c := newCSVConfig(options)
+	cr := csv.NewReader(r)
+	cr.Comma = c.comma
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+
+	n := 0
+	if c.skipHeader {
+		// The header may have a different number of fields to
+		// the records, so the field count is checked only from
+		// the first record after it.
+		cr.FieldsPerRecord = -1
+		_, err := cr.Read()
+		if err == io.EOF {
+			return ErrZeroLength
+		}
+		if err != nil {
+			return err
+		}
+		cr.FieldsPerRecord = 0
+		n++
+	}
+
+	var (
+		data []float64
+		rows int
+		cols int
+	)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if pe, ok := err.(*csv.ParseError); ok && pe.Err == csv.ErrFieldCount {
+			return fmt.Errorf("mat: ragged CSV input at line %d: got %d fields, want %d", pe.Line, len(record), cr.FieldsPerRecord)
+		}
+		if err != nil {
+			return err
+		}
+		n++
+		if rows == 0 {
+			cols = len(record)
+		}
+		for j, f := range record {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return fmt.Errorf("mat: invalid CSV value %q at record %d, field %d: %v", f, n, j+1, err)
+			}
+			data = append(data, v)
+		}
+		rows++
+	}
+	if rows == 0 || cols == 0 {
+		return ErrZeroLength
+	}
+
+	m.reuseAsNonZeroed(rows, cols)
+	copy(m.mat.Data, data)
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDenseCSVRoundTrip(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		m       *Dense
+		options []CSVOption
+		want    string
+	}{
+		{
+			m:    NewDense(1, 1, []float64{1}),
+			want: "1\n",
+		},
+		{
+			m: NewDense(2, 3, []float64{
+				1, 2.5, -3,
+				0.1, math.Inf(1), 1e-300,
+			}),
+			want: "1,2.5,-3\n0.1,+Inf,1e-300\n",
+		},
+		{
+			m: NewDense(2, 2, []float64{
+				1, 2,
+				3, 4,
+			}),
+			options: []CSVOption{CSVComma('\t')},
+			want:    "1\t2\n3\t4\n",
+		},
+		{
+			m: NewDense(4, 4, []float64{
+				1, 2, 3, 4,
+				5, 6, 7, 8,
+				9, 10, 11, 12,
+				13, 14, 15, 16,
+			}).Slice(1, 3, 1, 4).(*Dense),
+			want: "6,7,8\n10,11,12\n",
+		},
+	} {
+		var buf bytes.Buffer
+		err := test.m.MarshalCSV(&buf, test.options...)
+		if err != nil {
+			t.Errorf("test %d: unexpected error marshaling: %v", i, err)
+			continue
+		}
+		if buf.String() != test.want {
+			t.Errorf("test %d: unexpected CSV:\ngot:\n%s\nwant:\n%s", i, buf.String(), test.want)
+		}
+
+		var got Dense
+		err = got.UnmarshalCSV(&buf, test.options...)
+		if err != nil {
+			t.Errorf("test %d: unexpected error unmarshaling: %v", i, err)
+			continue
+		}
+		if !Equal(&got, test.m) {
+			t.Errorf("test %d: round trip mismatch:\ngot:\n%v\nwant:\n%v", i, Formatted(&got), Formatted(test.m))
+		}
+	}
+}
+
+func TestDenseUnmarshalCSV(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		in      string
+		options []CSVOption
+		want    *Dense
+		err     string
+	}{
+		{
+			in:      "a,b\n1,2\n3,4\n",
+			options: []CSVOption{CSVSkipHeader()},
+			want:    NewDense(2, 2, []float64{1, 2, 3, 4}),
+		},
+		{
+			in:      "a;b;c\n1; 2; 3\n",
+			options: []CSVOption{CSVSkipHeader(), CSVComma(';')},
+			want:    NewDense(1, 3, []float64{1, 2, 3}),
+		},
+		{
+			in:  "1,2\n3,4,5\n",
+			err: "mat: ragged CSV input at line 2: got 3 fields, want 2",
+		},
+		{
+			in:      "x\n1,2\n3,4\n5\n",
+			options: []CSVOption{CSVSkipHeader()},
+			err:     "mat: ragged CSV input at line 4: got 1 fields, want 2",
+		},
+		{
+			in:  "1,2\n3,x\n",
+			err: `mat: invalid CSV value "x" at record 2, field 2: strconv.ParseFloat: parsing "x": invalid syntax`,
+		},
+		{
+			in:  "1,2\n\n3,4\n\"5\n\",6\n",
+			err: `mat: invalid CSV value "5\n" at record 3, field 1: strconv.ParseFloat: parsing "5\n": invalid syntax`,
+		},
+		{
+			in:  "1,2\n\n3,4\n\n5\n",
+			err: "mat: ragged CSV input at line 5: got 1 fields, want 2",
+		},
+		{
+			in:  "",
+			err: ErrZeroLength.Error(),
+		},
+		{
+			in:      "a,b\n",
+			options: []CSVOption{CSVSkipHeader()},
+			err:     ErrZeroLength.Error(),
+		},
+	} {
+		var got Dense
+		err := got.UnmarshalCSV(strings.NewReader(test.in), test.options...)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("test %d: unexpected error: got: %v want: %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if !Equal(&got, test.want) {
+			t.Errorf("test %d: unexpected result:\ngot:\n%v\nwant:\n%v", i, Formatted(&got), Formatted(test.want))
+		}
+	}
+
+	m := NewDense(1, 1, nil)
+	if panicked, _ := panics(func() { _ = m.UnmarshalCSV(strings.NewReader("1\n")) }); !panicked {
+		t.Error("expected panic for unmarshal into non-empty matrix")
+	}
+}
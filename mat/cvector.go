@@ -0,0 +1,216 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "gonum.org/v1/gonum/blas/cblas128"
+
+var (
+	cVector *CVecDense
+
+	_ CMatrix = cVector
+)
+
+// CVecDense represents a column vector with complex data.
+type CVecDense struct {
+	mat cblas128.Vector
+	// A BLAS vector can have a negative increment, but allowing this
+	// in the mat type complicates a lot of code, and doesn't gain anything.
+	// CVecDense must have positive increment in this package.
+}
+
+// NewCVecDense creates a new CVecDense of length n. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n, data is
+// used as the backing slice, and changes to the elements of the returned
+// CVecDense will be reflected in data. If neither of these is true,
+// NewCVecDense will panic. NewCVecDense will panic if n is zero.
+func NewCVecDense(n int, data []complex128) *CVecDense {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic(ErrNegativeDimension)
+	}
+	if len(data) != n && data != nil {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]complex128, n)
+	}
+	return &CVecDense{
+		mat: cblas128.Vector{
+			N:    n,
+			Inc:  1,
+			Data: data,
+		},
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix. Columns is always 1
+// for a non-Reset vector.
+func (v *CVecDense) Dims() (r, c int) {
+	if v.IsEmpty() {
+		return 0, 0
+	}
+	return v.mat.N, 1
+}
+
+// Len returns the length of the vector.
+func (v *CVecDense) Len() int {
+	return v.mat.N
+}
+
+// H performs an implicit conjugate transpose by returning the receiver inside
+// a Conjugate.
+func (v *CVecDense) H() CMatrix {
+	return Conjugate{v}
+}
+
+// Reset zeros the length of the vector so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// Reset should not be used when the vector shares backing data.
+// See the Reseter interface for more information.
+func (v *CVecDense) Reset() {
+	// No change of Inc or N to 0 may be
+	// made unless both are set to 0.
+	v.mat.Inc = 0
+	v.mat.N = 0
+	v.mat.Data = v.mat.Data[:0]
+}
+
+// IsEmpty returns whether the receiver is empty. Empty vectors can be the
+// receiver for size-restricted operations. The receiver can be emptied using
+// Reset.
+func (v *CVecDense) IsEmpty() bool {
+	// It must be the case that v.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return v.mat.Inc == 0
+}
+
+// Zero sets all of the vector elements to zero.
+func (v *CVecDense) Zero() {
+	for i := 0; i < v.mat.N; i++ {
+		v.mat.Data[v.mat.Inc*i] = 0
+	}
+}
+
+// RawCVector returns the underlying cblas128.Vector used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned cblas128.Vector.
+func (v *CVecDense) RawCVector() cblas128.Vector {
+	return v.mat
+}
+
+// CopyVec makes a copy of elements of a into the receiver. It is similar to
+// the built-in copy; it copies as much as the overlap between the two vectors
+// and returns the number of elements it copied.
+func (v *CVecDense) CopyVec(a *CVecDense) int {
+	n := min(v.Len(), a.Len())
+	if v == a || n == 0 {
+		return n
+	}
+	src := a.mat
+	src.N = n
+	dst := v.mat
+	dst.N = n
+	cblas128.Copy(src, dst)
+	return n
+}
+
+// AddVec adds the vectors a and b, placing the result in the receiver.
+func (v *CVecDense) AddVec(a, b *CVecDense) {
+	n := a.Len()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	v.reuseAsNonZeroed(n)
+	if v != a {
+		v.checkOverlap(a.mat)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+
+	switch {
+	case v == a:
+		cblas128.Axpy(1, b.mat, v.mat)
+	case v == b:
+		cblas128.Axpy(1, a.mat, v.mat)
+	default:
+		cblas128.Copy(a.mat, v.mat)
+		cblas128.Axpy(1, b.mat, v.mat)
+	}
+}
+
+// ScaleVec scales the vector a by alpha, placing the result in the receiver.
+func (v *CVecDense) ScaleVec(alpha complex128, a *CVecDense) {
+	n := a.Len()
+	v.reuseAsNonZeroed(n)
+	if v != a {
+		v.checkOverlap(a.mat)
+		cblas128.Copy(a.mat, v.mat)
+	}
+	cblas128.Scal(alpha, v.mat)
+}
+
+// Norm returns the Euclidean norm of the receiver, sqrt(Σ |v[i]|²).
+// Norm panics with ErrZeroLength if the receiver is empty.
+func (v *CVecDense) Norm() float64 {
+	if v.IsEmpty() {
+		panic(ErrZeroLength)
+	}
+	return cblas128.Nrm2(v.mat)
+}
+
+// CDot returns the unconjugated dot product of a and b, Σ a[i]*b[i].
+// CDot panics with ErrShape if the vector sizes are unequal.
+func CDot(a, b *CVecDense) complex128 {
+	if a.Len() != b.Len() {
+		panic(ErrShape)
+	}
+	if a.IsEmpty() {
+		return 0
+	}
+	return cblas128.Dotu(a.mat, b.mat)
+}
+
+// CDotc returns the dot product of a and b with a conjugated,
+// Σ conj(a[i])*b[i]. This is the inner product of a and b.
+// CDotc panics with ErrShape if the vector sizes are unequal.
+func CDotc(a, b *CVecDense) complex128 {
+	if a.Len() != b.Len() {
+		panic(ErrShape)
+	}
+	if a.IsEmpty() {
+		return 0
+	}
+	return cblas128.Dotc(a.mat, b.mat)
+}
+
+// reuseAsNonZeroed resizes an empty vector to a r×1 vector,
+// or checks that a non-empty vector is r×1.
+func (v *CVecDense) reuseAsNonZeroed(r int) {
+	if r == 0 {
+		panic(ErrZeroLength)
+	}
+	if v.IsEmpty() {
+		v.mat = cblas128.Vector{
+			N:    r,
+			Inc:  1,
+			Data: useC(v.mat.Data, r),
+		}
+		return
+	}
+	if r != v.mat.N {
+		panic(ErrShape)
+	}
+}
+
+func (v *CVecDense) checkOverlap(a cblas128.Vector) bool {
+	return checkOverlapComplex(
+		cblas128.General{Rows: v.mat.N, Cols: 1, Stride: v.mat.Inc, Data: v.mat.Data},
+		cblas128.General{Rows: a.N, Cols: 1, Stride: a.Inc, Data: a.Data},
+	)
+}
@@ -0,0 +1,112 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewCVecDense(t *testing.T) {
+	t.Parallel()
+	v := NewCVecDense(3, []complex128{1, 2i, 3 + 1i})
+	if r, c := v.Dims(); r != 3 || c != 1 {
+		t.Errorf("unexpected dims: got: %d×%d want: 3×1", r, c)
+	}
+	if got := v.AtVec(1); got != 2i {
+		t.Errorf("unexpected element: got: %v want: 2i", got)
+	}
+	v.SetVec(1, -1)
+	if got := v.At(1, 0); got != -1 {
+		t.Errorf("unexpected element after SetVec: got: %v want: -1", got)
+	}
+	if got := v.H().At(0, 2); got != 3-1i {
+		t.Errorf("unexpected conjugate transpose element: got: %v want: 3-1i", got)
+	}
+
+	for _, n := range []int{-1, 0} {
+		if panicked, _ := panics(func() { NewCVecDense(n, nil) }); !panicked {
+			t.Errorf("expected panic for n=%d", n)
+		}
+	}
+	if panicked, _ := panics(func() { NewCVecDense(2, make([]complex128, 3)) }); !panicked {
+		t.Error("expected panic for data length mismatch")
+	}
+	if panicked, _ := panics(func() { v.AtVec(3) }); !panicked {
+		t.Error("expected panic for out of range access")
+	}
+}
+
+func TestCVecDenseAddScaleVec(t *testing.T) {
+	t.Parallel()
+	a := NewCVecDense(3, []complex128{1, 1i, 2 - 1i})
+	b := NewCVecDense(3, []complex128{1i, 1, -2})
+
+	var v CVecDense
+	v.AddVec(a, b)
+	want := NewCVecDense(3, []complex128{1 + 1i, 1 + 1i, -1i})
+	if !CEqual(&v, want) {
+		t.Errorf("unexpected AddVec result: got: %v want: %v", v.mat.Data, want.mat.Data)
+	}
+
+	v.AddVec(&v, &v)
+	want = NewCVecDense(3, []complex128{2 + 2i, 2 + 2i, -2i})
+	if !CEqual(&v, want) {
+		t.Errorf("unexpected aliased AddVec result: got: %v want: %v", v.mat.Data, want.mat.Data)
+	}
+
+	var s CVecDense
+	s.ScaleVec(1i, a)
+	want = NewCVecDense(3, []complex128{1i, -1, 1 + 2i})
+	if !CEqual(&s, want) {
+		t.Errorf("unexpected ScaleVec result: got: %v want: %v", s.mat.Data, want.mat.Data)
+	}
+	s.ScaleVec(2, &s)
+	want = NewCVecDense(3, []complex128{2i, -2, 2 + 4i})
+	if !CEqual(&s, want) {
+		t.Errorf("unexpected in-place ScaleVec result: got: %v want: %v", s.mat.Data, want.mat.Data)
+	}
+
+	// Strided operands.
+	x := &CVecDense{mat: a.mat}
+	x.mat.Data = []complex128{1, 0, 1i, 0, 2 - 1i}
+	x.mat.Inc = 2
+	v.Reset()
+	v.AddVec(x, b)
+	want = NewCVecDense(3, []complex128{1 + 1i, 1 + 1i, -1i})
+	if !CEqual(&v, want) {
+		t.Errorf("unexpected strided AddVec result: got: %v want: %v", v.mat.Data, want.mat.Data)
+	}
+
+	if panicked, _ := panics(func() { v.AddVec(a, NewCVecDense(2, nil)) }); !panicked {
+		t.Error("expected panic for length mismatch")
+	}
+}
+
+func TestCVecDenseDotNorm(t *testing.T) {
+	t.Parallel()
+	a := NewCVecDense(2, []complex128{1 + 1i, 2})
+	b := NewCVecDense(2, []complex128{1i, 3 - 1i})
+
+	if got, want := CDot(a, b), complex128(-1+1i+6-2i); got != want {
+		t.Errorf("unexpected CDot result: got: %v want: %v", got, want)
+	}
+	if got, want := CDotc(a, b), complex128(1+1i+6-2i); got != want {
+		t.Errorf("unexpected CDotc result: got: %v want: %v", got, want)
+	}
+	if got, want := CDotc(a, a), complex128(6); got != want {
+		t.Errorf("unexpected CDotc self result: got: %v want: %v", got, want)
+	}
+	if got, want := a.Norm(), math.Sqrt(6); math.Abs(got-want) > 1e-15 {
+		t.Errorf("unexpected Norm result: got: %v want: %v", got, want)
+	}
+
+	if panicked, _ := panics(func() { CDot(a, NewCVecDense(1, nil)) }); !panicked {
+		t.Error("expected panic for length mismatch")
+	}
+	if panicked, _ := panics(func() { new(CVecDense).Norm() }); !panicked {
+		t.Error("expected panic for empty receiver")
+	}
+}
@@ -276,6 +276,145 @@ func (m *Dense) SetRow(i int, src []float64) {
 	copy(m.rawRowView(i), src)
 }
 
+// SwapRows exchanges the contents of rows i and j of the receiver in place.
+// SwapRows panics with ErrIndexOutOfRange if i or j is outside the rows of
+// the receiver.
+func (m *Dense) SwapRows(i, j int) {
+	if uint(i) >= uint(m.mat.Rows) || uint(j) >= uint(m.mat.Rows) {
+		panic(ErrIndexOutOfRange)
+	}
+	if i == j {
+		return
+	}
+	blas64.Swap(
+		blas64.Vector{N: m.mat.Cols, Inc: 1, Data: m.rawRowView(i)},
+		blas64.Vector{N: m.mat.Cols, Inc: 1, Data: m.rawRowView(j)},
+	)
+}
+
+// SwapCols exchanges the contents of columns i and j of the receiver in place.
+// SwapCols panics with ErrIndexOutOfRange if i or j is outside the columns of
+// the receiver.
+func (m *Dense) SwapCols(i, j int) {
+	if uint(i) >= uint(m.mat.Cols) || uint(j) >= uint(m.mat.Cols) {
+		panic(ErrIndexOutOfRange)
+	}
+	if i == j {
+		return
+	}
+	blas64.Swap(
+		blas64.Vector{N: m.mat.Rows, Inc: m.mat.Stride, Data: m.mat.Data[i:]},
+		blas64.Vector{N: m.mat.Rows, Inc: m.mat.Stride, Data: m.mat.Data[j:]},
+	)
+}
+
+// Reshape changes the shape of the receiver to rows×cols, keeping its
+// elements in row-major order. If the receiver's rows are stored
+// contiguously, that is its stride equals its number of columns, the
+// receiver is resliced in place and continues to share backing data with
+// any matrix it was derived from. Otherwise, as is the case for views with
+// a stride larger than their number of columns, the elements are copied
+// into a new contiguous backing slice and the receiver no longer shares
+// data with the original matrix.
+//
+// Reshape panics with ErrShape if rows*cols is not equal to the number of
+// elements in the receiver, and panics if rows or cols is not positive.
+func (m *Dense) Reshape(rows, cols int) {
+	if rows <= 0 || cols <= 0 {
+		if rows == 0 || cols == 0 {
+			panic(ErrZeroLength)
+		}
+		panic(ErrNegativeDimension)
+	}
+	r, c := m.mat.Rows, m.mat.Cols
+	if rows*cols != r*c {
+		panic(ErrShape)
+	}
+	data := m.mat.Data
+	if m.mat.Stride != c {
+		data = make([]float64, r*c)
+		for i := 0; i < r; i++ {
+			copy(data[i*c:(i+1)*c], m.rawRowView(i))
+		}
+	}
+	m.mat = blas64.General{
+		Rows:   rows,
+		Cols:   cols,
+		Stride: cols,
+		Data:   data[:rows*cols],
+	}
+	m.capRows = rows
+	m.capCols = cols
+}
+
+// OneHot sets the receiver to the one-hot encoding of labels. The receiver
+// is sized to len(labels)×classes, or must already be of that size, and
+// element (i, labels[i]) is set to one for each i with all other elements
+// zero. If classes is not positive, the number of classes is taken to be
+// one more than the largest label.
+//
+// OneHot panics with ErrIndexOutOfRange if any label is negative or not less
+// than classes.
+func (m *Dense) OneHot(labels []int, classes int) {
+	if classes <= 0 {
+		for _, l := range labels {
+			classes = max(classes, l+1)
+		}
+	}
+	for _, l := range labels {
+		if l < 0 || classes <= l {
+			panic(ErrIndexOutOfRange)
+		}
+	}
+	m.reuseAsZeroed(len(labels), classes)
+	for i, l := range labels {
+		m.mat.Data[i*m.mat.Stride+l] = 1
+	}
+}
+
+// PermuteRows permutes the rows of the receiver in place according to p.
+// If inverse is false, row i of the result is row p[i] of the original
+// matrix, which is equivalent to multiplying the receiver on the left by the
+// permutation matrix constructed by Permutation with swaps equal to p. If
+// inverse is true, the inverse permutation is applied, so that row p[i] of
+// the result is row i of the original matrix.
+//
+// PermuteRows panics if p is not a permutation of 0..r-1, where r is the
+// number of rows of the receiver.
+func (m *Dense) PermuteRows(p []int, inverse bool) {
+	r, c := m.mat.Rows, m.mat.Cols
+	checkPermutation(p, r)
+	if r == 0 {
+		return
+	}
+	w := getWorkspace(r, c, false)
+	w.Copy(m)
+	if inverse {
+		for i, pi := range p {
+			copy(m.rawRowView(pi), w.rawRowView(i))
+		}
+	} else {
+		for i, pi := range p {
+			copy(m.rawRowView(i), w.rawRowView(pi))
+		}
+	}
+	putWorkspace(w)
+}
+
+// checkPermutation panics if p is not a permutation of 0..n-1.
+func checkPermutation(p []int, n int) {
+	if len(p) != n {
+		panic(badSliceLength)
+	}
+	seen := make([]bool, n)
+	for _, v := range p {
+		if v < 0 || n <= v || seen[v] {
+			panic(badPermutation)
+		}
+		seen[v] = true
+	}
+}
+
 // RowView returns row i of the matrix data represented as a column vector,
 // backed by the matrix data.
 //
@@ -315,8 +454,9 @@ func (m *Dense) DiagView() Diagonal {
 // The returned matrix starts at {i,j} of the receiver and extends k-i rows
 // and l-j columns. The final row in the resulting matrix is k-1 and the
 // final column is l-1.
-// Slice panics with ErrIndexOutOfRange if the slice is outside the capacity
-// of the receiver.
+// Slice panics with ErrZeroLength if i == k or j == l, since the resulting
+// matrix would be empty, and with ErrIndexOutOfRange if the slice is outside
+// the capacity of the receiver.
 func (m *Dense) Slice(i, k, j, l int) Matrix {
 	return m.slice(i, k, j, l)
 }
@@ -329,6 +469,9 @@ func (m *Dense) slice(i, k, j, l int) *Dense {
 		}
 		panic(ErrIndexOutOfRange)
 	}
+	if i == k || j == l {
+		panic(ErrZeroLength)
+	}
 	t := *m
 	t.mat.Data = t.mat.Data[i*t.mat.Stride+j : (k-1)*t.mat.Stride+l]
 	t.mat.Rows = k - i
@@ -407,6 +550,10 @@ func (m *Dense) Grow(r, c int) Matrix {
 // the receiver. The clone from operation does not make any restriction on shape and
 // will not cause shadowing.
 //
+// The receiver is always given newly allocated contiguous storage, so a strided
+// view, such as one returned by Slice, is compacted and the result never shares
+// backing data with a.
+//
 // See the ClonerFrom interface for more information.
 func (m *Dense) CloneFrom(a Matrix) {
 	r, c := a.Dims()
@@ -535,6 +682,48 @@ func (m *Dense) Copy(a Matrix) (r, c int) {
 	return r, c
 }
 
+// SetSlice copies the elements of a into the receiver with the top-left
+// element of a placed at row i and column j. SetSlice panics with
+// ErrIndexOutOfRange if a does not fit within the receiver at (i, j).
+//
+// The source may share backing data with the receiver, for example when it
+// is a view obtained from Slice, RowView or ColView of the receiver; the
+// result is as if a had been copied before writing.
+func (m *Dense) SetSlice(i, j int, a Matrix) {
+	r, c := a.Dims()
+	if i < 0 || j < 0 || r > m.mat.Rows-i || c > m.mat.Cols-j {
+		panic(ErrIndexOutOfRange)
+	}
+	dst := m.slice(i, i+r, j, j+c)
+
+	// Copy handles overlapping untransposed Dense sources by
+	// choosing the copy direction. Other overlapping sources
+	// are copied into a workspace first.
+	var data []float64
+	switch aU, trans := untransposeExtract(a); aU := aU.(type) {
+	case *Dense:
+		if trans {
+			data = aU.mat.Data
+		}
+	case *VecDense:
+		data = aU.mat.Data
+	}
+	if len(data) != 0 && overlaps(m.mat.Data, data) {
+		w := getWorkspace(r, c, false)
+		defer putWorkspace(w)
+		w.Copy(a)
+		a = w
+	}
+	dst.Copy(a)
+}
+
+// overlaps returns whether the slices a and b share any backing elements.
+// a and b must not be empty.
+func overlaps(a, b []float64) bool {
+	off := offset(a[:1], b[:1])
+	return (off >= 0 && off < len(a)) || (off < 0 && -off < len(b))
+}
+
 // Stack appends the rows of b onto the rows of a, placing the result into the
 // receiver with b placed in the greater indexed rows. Stack will panic if the
 // two input matrices do not have the same number of columns or the constructed
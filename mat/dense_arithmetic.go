@@ -9,6 +9,7 @@ import (
 
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/internal/asm/f64"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
@@ -214,6 +215,9 @@ func (m *Dense) DivElem(a, b Matrix) {
 // receiver. If a is ill-conditioned, a Condition error will be returned.
 // Note that matrix inversion is numerically unstable, and should generally
 // be avoided where possible, for example by using the Solve routines.
+//
+// If a is Symmetric and positive definite, the inverse is computed from its
+// Cholesky factorization, otherwise an LU factorization is used.
 func (m *Dense) Inverse(a Matrix) error {
 	// TODO(btracey): Special case for RawTriangular, etc.
 	r, c := a.Dims()
@@ -221,6 +225,16 @@ func (m *Dense) Inverse(a Matrix) error {
 		panic(ErrSquare)
 	}
 	m.reuseAsNonZeroed(a.Dims())
+	if s, ok := a.(Symmetric); ok {
+		var chol Cholesky
+		if chol.Factorize(s) {
+			w := getWorkspaceSym(r, false)
+			defer putWorkspaceSym(w)
+			err := chol.InverseTo(w)
+			m.Copy(w)
+			return err
+		}
+	}
 	aU, aTrans := untransposeExtract(a)
 	switch rm := aU.(type) {
 	case *Dense:
@@ -266,6 +280,51 @@ func (m *Dense) Inverse(a Matrix) error {
 	return nil
 }
 
+// PseudoInverse computes the Moore-Penrose pseudo-inverse of the r×c matrix a
+// using its singular value decomposition, placing the c×r result in the
+// receiver,
+//  m = V * Σ⁺ * Uᵀ,
+// where Σ⁺ holds the reciprocals of the singular values of a that are greater
+// than tol, and zero in place of those that are not. If tol is not positive,
+// the default tolerance described by Rank is used. Singular values that are
+// truncated do not contribute to the result, so rank-deficient and
+// ill-conditioned matrices produce finite pseudo-inverses.
+//
+// PseudoInverse panics if the singular value decomposition fails.
+func (m *Dense) PseudoInverse(a Matrix, tol float64) {
+	r, c := a.Dims()
+	if !m.IsEmpty() {
+		if mr, mc := m.Dims(); mr != c || mc != r {
+			panic(ErrShape)
+		}
+	}
+
+	// Factorize before touching the receiver so
+	// that it is left unchanged on failure.
+	var svd SVD
+	if !svd.Factorize(a, SVDThin) {
+		panic("mat: SVD factorization failed")
+	}
+	m.reuseAsNonZeroed(c, r)
+	rank := svdRank(svd.s, r, c, tol)
+	if rank == 0 {
+		m.Zero()
+		return
+	}
+
+	var u, v Dense
+	svd.UTo(&u)
+	svd.VTo(&v)
+	vs := v.Slice(0, c, 0, rank).(*Dense)
+	for i := 0; i < c; i++ {
+		row := vs.rawRowView(i)
+		for j, s := range svd.s[:rank] {
+			row[j] /= s
+		}
+	}
+	m.Mul(vs, u.Slice(0, r, 0, rank).T())
+}
+
 // Mul takes the matrix product of a and b, placing the result in the receiver.
 // If the number of columns in a does not equal the number of rows in b, Mul will panic.
 func (m *Dense) Mul(a, b Matrix) {
@@ -450,6 +509,125 @@ func (m *Dense) Mul(a, b Matrix) {
 	}
 }
 
+// MulAccum adds the matrix product of a and b scaled by alpha to the
+// receiver.
+//  m = alpha * a * b + m
+// The receiver must already have dimensions equal to the rows of a by the
+// columns of b; MulAccum panics with ErrShape otherwise. If the receiver
+// is a or b, the product is formed in temporary memory before it is added.
+func (m *Dense) MulAccum(alpha float64, a, b Matrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ac != br {
+		panic(ErrShape)
+	}
+	if m.IsEmpty() || m.mat.Rows != ar || m.mat.Cols != bc {
+		panic(ErrShape)
+	}
+
+	aU, aTrans := untransposeExtract(a)
+	bU, bTrans := untransposeExtract(b)
+	if aU, ok := aU.(*Dense); ok && m != aU {
+		if bU, ok := bU.(*Dense); ok && m != bU {
+			m.checkOverlap(aU.mat)
+			m.checkOverlap(bU.mat)
+			aT := blas.NoTrans
+			if aTrans {
+				aT = blas.Trans
+			}
+			bT := blas.NoTrans
+			if bTrans {
+				bT = blas.Trans
+			}
+			blas64.Gemm(aT, bT, alpha, aU.mat, bU.mat, 1, m.mat)
+			return
+		}
+	}
+
+	w := getWorkspace(ar, bc, false)
+	defer putWorkspace(w)
+	w.Mul(a, b)
+	for i := 0; i < ar; i++ {
+		wRow := w.mat.Data[i*w.mat.Stride : i*w.mat.Stride+bc]
+		mRow := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+bc]
+		f64.AxpyUnitary(alpha, wRow, mRow)
+	}
+}
+
+// DistanceMatrix places the pairwise distances between the rows of a into
+// the receiver, so that m[i,j] = metric(row i of a, row j of a). The receiver
+// must be n×n or empty, where n is the number of rows of a. If symmetric is
+// true, metric is assumed to satisfy metric(x, y) == metric(y, x) and is only
+// evaluated for the upper triangle and diagonal of the receiver, with the
+// lower triangle filled by reflection.
+//
+// The vectors passed to metric must not be retained or modified. If the
+// rows of a are not directly accessible they are copied once before the
+// distances are computed.
+func (m *Dense) DistanceMatrix(a Matrix, metric func(x, y Vector) float64, symmetric bool) {
+	n, _ := a.Dims()
+	m.reuseAsNonZeroed(n, n)
+	m.checkOverlapMatrix(a)
+
+	rows := make([]VecDense, n)
+	rm, ok := a.(RawMatrixer)
+	if !ok {
+		rm = DenseCopyOf(a)
+	}
+	for i := range rows {
+		rows[i].RowViewOf(rm, i)
+	}
+
+	for i := 0; i < n; i++ {
+		start := 0
+		if symmetric {
+			start = i
+		}
+		for j := start; j < n; j++ {
+			d := metric(&rows[i], &rows[j])
+			m.set(i, j, d)
+			if symmetric {
+				m.set(j, i, d)
+			}
+		}
+	}
+}
+
+// RandProject projects the rows of the n×d matrix a into k dimensions using
+// a random Gaussian projection, placing the n×k result in the receiver.
+// The result is
+//  m = a * R / sqrt(k)
+// where R is a d×k matrix with elements drawn independently from the standard
+// normal distribution. By the Johnson-Lindenstrauss lemma, distances between
+// the rows of a are approximately preserved between the rows of the result.
+//
+// The elements of R are drawn by calling normFloat64 in row-major order, so
+// the projection is reproducible for a given sampler state. normFloat64 must
+// return samples from the standard normal distribution, for example the
+// NormFloat64 method of a *rand.Rand.
+//
+// RandProject panics if k is not positive or if normFloat64 is nil.
+func (m *Dense) RandProject(a Matrix, k int, normFloat64 func() float64) {
+	if k <= 0 {
+		if k == 0 {
+			panic(ErrZeroLength)
+		}
+		panic(ErrNegativeDimension)
+	}
+	if normFloat64 == nil {
+		panic("mat: nil sampler")
+	}
+	_, d := a.Dims()
+
+	r := getWorkspace(d, k, false)
+	for i := range r.mat.Data {
+		r.mat.Data[i] = normFloat64()
+	}
+	m.Mul(a, r)
+	putWorkspace(r)
+	m.Scale(1/math.Sqrt(float64(k)), m)
+}
+
 // strictCopy copies a into m panicking if the shape of a and m differ.
 func strictCopy(m *Dense, a Matrix) {
 	r, c := m.Copy(a)
@@ -623,7 +801,8 @@ func (m *Dense) Exp(a Matrix) {
 }
 
 // Pow calculates the integral power of the matrix a to n, placing the result
-// in the receiver. Pow will panic if n is negative or if a is not square.
+// in the receiver. The power is computed by repeated squaring, and the receiver
+// may be a. Pow will panic if n is negative or if a is not square.
 func (m *Dense) Pow(a Matrix, n int) {
 	if n < 0 {
 		panic("mat: illegal power")
@@ -688,6 +867,8 @@ func (m *Dense) Kronecker(a, b Matrix) {
 }
 
 // Scale multiplies the elements of a by f, placing the result in the receiver.
+// When a is the receiver, the elements are scaled in place without temporary
+// storage, and the receiver is left unchanged if f is one.
 //
 // See the Scaler interface for more information.
 func (m *Dense) Scale(f float64, a Matrix) {
@@ -695,6 +876,20 @@ func (m *Dense) Scale(f float64, a Matrix) {
 
 	m.reuseAsNonZeroed(ar, ac)
 
+	if m == a {
+		if f == 1 {
+			return
+		}
+		if m.mat.Stride == ac {
+			f64.ScalUnitary(f, m.mat.Data[:ar*ac])
+			return
+		}
+		for i := 0; i < ar; i++ {
+			f64.ScalUnitary(f, m.rawRowView(i))
+		}
+		return
+	}
+
 	aU, aTrans := untransposeExtract(a)
 	if rm, ok := aU.(*Dense); ok {
 		amat := rm.mat
@@ -727,6 +922,279 @@ func (m *Dense) Scale(f float64, a Matrix) {
 	}
 }
 
+// Standardize transforms each column of a to have zero mean and unit
+// standard deviation, placing the result in the receiver. It returns the
+// means and standard deviations of the columns of a so that the same
+// transform can be applied to other data, or inverted with
+//  a[i][j] = m[i][j]*std[j] + mean[j].
+//
+// The standard deviation is the sample standard deviation, normalized by
+// r-1 where r is the number of rows of a, and is zero when a has a single
+// row. Columns with zero standard deviation are centered but not scaled,
+// which is equivalent to dividing by one, so that no NaN values are
+// produced; the returned std holds zero for these columns.
+func (m *Dense) Standardize(a Matrix) (mean, std *VecDense) {
+	r, c := a.Dims()
+	m.reuseAsNonZeroed(r, c)
+	m.Copy(a)
+
+	mean = NewVecDense(c, nil)
+	std = NewVecDense(c, nil)
+	mu := mean.mat.Data
+	sd := std.mat.Data
+	for i := 0; i < r; i++ {
+		f64.AxpyUnitary(1, m.rawRowView(i), mu)
+	}
+	f64.ScalUnitary(1/float64(r), mu)
+	for i := 0; i < r; i++ {
+		row := m.rawRowView(i)
+		f64.AxpyUnitary(-1, mu, row)
+		for j, v := range row {
+			sd[j] += v * v
+		}
+	}
+	for j := range sd {
+		if r > 1 {
+			sd[j] = math.Sqrt(sd[j] / float64(r-1))
+		} else {
+			sd[j] = 0
+		}
+	}
+	for i := 0; i < r; i++ {
+		row := m.rawRowView(i)
+		for j, s := range sd {
+			if s != 0 {
+				row[j] /= s
+			}
+		}
+	}
+	return mean, std
+}
+
+// AddRowVec adds v to each row of a, placing the result in the receiver,
+//  m[i,j] = a[i,j] + v[j].
+// AddRowVec will panic if the length of v is not equal to the number of
+// columns of a. AddRowVec may be used in place.
+func (m *Dense) AddRowVec(a Matrix, v Vector) {
+	m.broadcastVec(a, v, false, func(row, w []float64, _ int) {
+		f64.AxpyUnitary(1, w, row)
+	})
+}
+
+// SubRowVec subtracts v from each row of a, placing the result in the receiver,
+//  m[i,j] = a[i,j] - v[j].
+// SubRowVec will panic if the length of v is not equal to the number of
+// columns of a. SubRowVec may be used in place.
+func (m *Dense) SubRowVec(a Matrix, v Vector) {
+	m.broadcastVec(a, v, false, func(row, w []float64, _ int) {
+		f64.AxpyUnitary(-1, w, row)
+	})
+}
+
+// AddColVec adds v to each column of a, placing the result in the receiver,
+//  m[i,j] = a[i,j] + v[i].
+// AddColVec will panic if the length of v is not equal to the number of
+// rows of a. AddColVec may be used in place.
+func (m *Dense) AddColVec(a Matrix, v Vector) {
+	m.broadcastVec(a, v, true, func(row, w []float64, i int) {
+		f64.AddConst(w[i], row)
+	})
+}
+
+// SubColVec subtracts v from each column of a, placing the result in the
+// receiver,
+//  m[i,j] = a[i,j] - v[i].
+// SubColVec will panic if the length of v is not equal to the number of
+// rows of a. SubColVec may be used in place.
+func (m *Dense) SubColVec(a Matrix, v Vector) {
+	m.broadcastVec(a, v, true, func(row, w []float64, i int) {
+		f64.AddConst(-w[i], row)
+	})
+}
+
+// ScaleRows multiplies each row of a by the corresponding element of v,
+// placing the result in the receiver,
+//  m[i,j] = v[i] * a[i,j].
+// This is the product diag(v) * a computed without forming the diagonal
+// matrix. ScaleRows will panic if the length of v is not equal to the number
+// of rows of a. ScaleRows may be used in place.
+func (m *Dense) ScaleRows(a Matrix, v Vector) {
+	m.broadcastVec(a, v, true, func(row, w []float64, i int) {
+		f64.ScalUnitary(w[i], row)
+	})
+}
+
+// ScaleCols multiplies each column of a by the corresponding element of v,
+// placing the result in the receiver,
+//  m[i,j] = a[i,j] * v[j].
+// This is the product a * diag(v) computed without forming the diagonal
+// matrix. ScaleCols will panic if the length of v is not equal to the number
+// of columns of a. ScaleCols may be used in place.
+func (m *Dense) ScaleCols(a Matrix, v Vector) {
+	m.broadcastVec(a, v, false, func(row, w []float64, _ int) {
+		for j, f := range w {
+			row[j] *= f
+		}
+	})
+}
+
+// broadcastVec copies a into the receiver and then calls op for each row i
+// of the receiver with the elements of v gathered into w. The length of v
+// must match the number of rows of a if col is true, and the number of
+// columns otherwise.
+func (m *Dense) broadcastVec(a Matrix, v Vector, col bool, op func(row, w []float64, i int)) {
+	ar, ac := a.Dims()
+	n := ac
+	if col {
+		n = ar
+	}
+	if v.Len() != n {
+		panic(ErrShape)
+	}
+
+	m.reuseAsNonZeroed(ar, ac)
+
+	// Gather v before writing to the receiver since v may be
+	// a view of a or of the receiver.
+	w := getFloats(n, false)
+	defer putFloats(w)
+	if rv, ok := v.(RawVectorer); ok {
+		blas64.Copy(rv.RawVector(), blas64.Vector{N: n, Inc: 1, Data: w})
+	} else {
+		for i := range w {
+			w[i] = v.AtVec(i)
+		}
+	}
+
+	if aU, aTrans := untransposeExtract(a); m == aU && aTrans {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+	m.Copy(a)
+
+	for i := 0; i < ar; i++ {
+		op(m.rawRowView(i), w, i)
+	}
+}
+
+// Threshold copies the elements of a into the receiver, replacing elements
+// with magnitude less than or equal to tol by zero. It returns the number of
+// non-zero elements that were replaced. Threshold may be used in place.
+func (m *Dense) Threshold(a Matrix, tol float64) int {
+	ar, ac := a.Dims()
+
+	m.reuseAsNonZeroed(ar, ac)
+
+	if aU, aTrans := untransposeExtract(a); m == aU && aTrans {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+	m.Copy(a)
+
+	var n int
+	for i := 0; i < ar; i++ {
+		row := m.rawRowView(i)
+		for j, v := range row {
+			if math.Abs(v) <= tol && v != 0 {
+				row[j] = 0
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// SoftThreshold applies the soft-thresholding operator with threshold lambda
+// to the elements of a, placing the result in the receiver. Each element is
+// shrunk toward zero by lambda,
+//  m[i,j] = sign(a[i,j]) * max(|a[i,j]| - lambda, 0),
+// which is the proximal operator of lambda times the L1 norm. NaN elements
+// are propagated. SoftThreshold may be used in place. SoftThreshold panics if
+// lambda is negative.
+func (m *Dense) SoftThreshold(a Matrix, lambda float64) {
+	if lambda < 0 {
+		panic("mat: negative soft threshold")
+	}
+	ar, ac := a.Dims()
+
+	m.reuseAsNonZeroed(ar, ac)
+
+	if aU, aTrans := untransposeExtract(a); m == aU && aTrans {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+	m.Copy(a)
+
+	for i := 0; i < ar; i++ {
+		row := m.rawRowView(i)
+		for j, v := range row {
+			row[j] = softThreshold(v, lambda)
+		}
+	}
+}
+
+// softThreshold returns sign(x) * max(|x| - lambda, 0).
+func softThreshold(x, lambda float64) float64 {
+	switch {
+	case math.IsNaN(x):
+		return x
+	case x > lambda:
+		return x - lambda
+	case x < -lambda:
+		return x + lambda
+	default:
+		return 0
+	}
+}
+
+// Orthonormalize applies modified Gram-Schmidt orthogonalization to the
+// columns of a, placing the orthonormalized columns in the receiver, and
+// returns the number of linearly independent columns found. Column j of the
+// receiver is a unit vector orthogonal to all preceding non-zero columns
+// and lies in the span of the first j+1 columns of a.
+//
+// A column of a is considered linearly dependent on the preceding columns if
+// the norm of its component orthogonal to them is no greater than tol times
+// its original norm. Dependent columns, including zero columns of a, are set
+// to zero in the receiver. Orthonormalize may be used in place.
+func (m *Dense) Orthonormalize(a Matrix, tol float64) (rank int) {
+	ar, ac := a.Dims()
+
+	m.reuseAsNonZeroed(ar, ac)
+
+	if aU, aTrans := untransposeExtract(a); m == aU && aTrans {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	}
+	m.Copy(a)
+
+	cols := make([]VecDense, ac)
+	for j := range cols {
+		cols[j].ColViewOf(m, j)
+	}
+	for j := range cols {
+		q := &cols[j]
+		norm := blas64.Nrm2(q.mat)
+		for k := 0; k < j; k++ {
+			// Zeroed dependent columns contribute nothing.
+			qk := cols[k].mat
+			blas64.Axpy(-blas64.Dot(qk, q.mat), qk, q.mat)
+		}
+		qNorm := blas64.Nrm2(q.mat)
+		if qNorm == 0 || qNorm <= tol*norm {
+			q.Zero()
+			continue
+		}
+		blas64.Scal(1/qNorm, q.mat)
+		rank++
+	}
+	return rank
+}
+
 // Apply applies the function fn to each of the elements of a, placing the
 // resulting matrix in the receiver. The function fn takes a row/column
 // index and element value and returns some function of that tuple.
@@ -767,6 +1235,68 @@ func (m *Dense) Apply(fn func(i, j int, v float64) float64, a Matrix) {
 	}
 }
 
+// ApplyErr applies the function fn to each of the elements of a, placing the
+// resulting matrix in the receiver, in the same way as Apply. The elements
+// are visited in row-major order. If fn returns a non-nil error, ApplyErr
+// stops and returns an ApplyError holding the error and the row and column
+// of the failing element. Elements of the receiver written before the
+// failure keep their new values. If the receiver was non-empty, the
+// remaining elements are unchanged; if it was empty, it is resized and the
+// contents of the remaining elements are unspecified.
+func (m *Dense) ApplyErr(fn func(i, j int, v float64) (float64, error), a Matrix) error {
+	ar, ac := a.Dims()
+
+	m.reuseAsNonZeroed(ar, ac)
+
+	dst := m
+	aU, aTrans := untransposeExtract(a)
+	if rm, ok := aU.(*Dense); ok {
+		amat := rm.mat
+		if m == aU && aTrans {
+			// Writing in place would overwrite elements of a
+			// that are yet to be read, so work on a copy of
+			// the receiver and write it back on return.
+			dst = getWorkspace(ar, ac, false)
+			dst.Copy(m)
+			defer func() {
+				m.Copy(dst)
+				putWorkspace(dst)
+			}()
+		} else if m != aU {
+			m.checkOverlap(amat)
+		}
+		for i := 0; i < ar; i++ {
+			row := dst.rawRowView(i)
+			for j := range row {
+				var v float64
+				if aTrans {
+					v = amat.Data[j*amat.Stride+i]
+				} else {
+					v = amat.Data[i*amat.Stride+j]
+				}
+				r, err := fn(i, j, v)
+				if err != nil {
+					return ApplyError{I: i, J: j, Err: err}
+				}
+				row[j] = r
+			}
+		}
+		return nil
+	}
+
+	m.checkOverlapMatrix(a)
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			r, err := fn(i, j, a.At(i, j))
+			if err != nil {
+				return ApplyError{I: i, J: j, Err: err}
+			}
+			m.set(i, j, r)
+		}
+	}
+	return nil
+}
+
 // RankOne performs a rank-one update to the matrix a with the vectors x and
 // y, where x and y are treated as column vectors. The result is stored in the
 // receiver. The Outer method can be used instead of RankOne if a is not needed.
@@ -865,3 +1395,56 @@ func (m *Dense) Outer(alpha float64, x, y Vector) {
 		}
 	}
 }
+
+// OuterDiff calculates the outer difference of the vectors x and y and stores
+// the result in the receiver.
+//  m[i, j] = x[i] - y[j]
+// The receiver is resized to x.Len()×y.Len() if it is empty.
+func (m *Dense) OuterDiff(x, y Vector) {
+	m.outerDiff(x, y, false)
+}
+
+// OuterSqDist calculates the squared differences between the elements of the
+// vectors x and y and stores the result in the receiver.
+//  m[i, j] = (x[i] - y[j])²
+// The receiver is resized to x.Len()×y.Len() if it is empty.
+func (m *Dense) OuterSqDist(x, y Vector) {
+	m.outerDiff(x, y, true)
+}
+
+func (m *Dense) outerDiff(x, y Vector, square bool) {
+	r, c := x.Len(), y.Len()
+
+	m.reuseAsNonZeroed(r, c)
+
+	xU, _ := untransposeExtract(x)
+	if rv, ok := xU.(*VecDense); ok {
+		r, c := xU.Dims()
+		m.checkOverlap(generalFromVector(rv.mat, r, c))
+	}
+	yU, _ := untransposeExtract(y)
+	if rv, ok := yU.(*VecDense); ok {
+		r, c := yU.Dims()
+		m.checkOverlap(generalFromVector(rv.mat, r, c))
+	}
+
+	yv := getFloats(c, false)
+	defer putFloats(yv)
+	for j := range yv {
+		yv[j] = y.AtVec(j)
+	}
+	for i := 0; i < r; i++ {
+		xi := x.AtVec(i)
+		row := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c]
+		if square {
+			for j, v := range yv {
+				d := xi - v
+				row[j] = d * d
+			}
+		} else {
+			for j, v := range yv {
+				row[j] = xi - v
+			}
+		}
+	}
+}
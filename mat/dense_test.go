@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -222,6 +223,36 @@ func TestDenseAtSet(t *testing.T) {
 	}
 }
 
+func TestDenseCloneFromView(t *testing.T) {
+	t.Parallel()
+	base := NewDense(4, 5, nil)
+	for i := range base.mat.Data {
+		base.mat.Data[i] = float64(i)
+	}
+	for _, a := range []Matrix{
+		base.Slice(1, 3, 1, 4),
+		base.Slice(1, 3, 1, 4).T(),
+		base.ColView(2),
+		base.RowView(1).T(),
+		asBasicMatrix(base.Slice(0, 2, 2, 5).(*Dense)),
+	} {
+		want := DenseCopyOf(a)
+		var m Dense
+		m.CloneFrom(a)
+		r, c := m.Dims()
+		if m.mat.Stride != c || len(m.mat.Data) != r*c {
+			t.Errorf("clone of %T not compacted: stride %d len %d for %d×%d", a, m.mat.Stride, len(m.mat.Data), r, c)
+		}
+		if !Equal(&m, want) {
+			t.Errorf("unexpected clone of %T:\ngot:\n%v\nwant:\n%v", a, Formatted(&m), Formatted(want))
+		}
+		m.Scale(-1, &m)
+		if !Equal(a, want) {
+			t.Errorf("modifying clone of %T altered the source", a)
+		}
+	}
+}
+
 func TestDenseSetRowColumn(t *testing.T) {
 	t.Parallel()
 	for _, as := range [][][]float64{
@@ -261,6 +292,314 @@ func TestDenseSetRowColumn(t *testing.T) {
 	}
 }
 
+func TestDenseSetSlice(t *testing.T) {
+	t.Parallel()
+	newM := func() *Dense {
+		m := NewDense(4, 5, nil)
+		for i := range m.mat.Data {
+			m.mat.Data[i] = float64(i + 1)
+		}
+		return m
+	}
+	for _, test := range []struct {
+		name string
+		i, j int
+		// src returns the source matrix, possibly a view of m.
+		src func(m *Dense) Matrix
+	}{
+		{
+			name: "independent",
+			i:    1, j: 2,
+			src: func(*Dense) Matrix { return NewDense(2, 3, []float64{-1, -2, -3, -4, -5, -6}) },
+		},
+		{
+			name: "independent transposed",
+			i:    0, j: 3,
+			src: func(*Dense) Matrix { return NewDense(2, 3, []float64{-1, -2, -3, -4, -5, -6}).T() },
+		},
+		{
+			name: "basic matrix",
+			i:    2, j: 0,
+			src: func(*Dense) Matrix { return asBasicMatrix(NewDense(2, 2, []float64{-1, -2, -3, -4})) },
+		},
+		{
+			name: "overlapping shift down",
+			i:    1, j: 1,
+			src: func(m *Dense) Matrix { return m.Slice(0, 3, 0, 4) },
+		},
+		{
+			name: "overlapping shift up",
+			i:    0, j: 0,
+			src: func(m *Dense) Matrix { return m.Slice(1, 4, 1, 5) },
+		},
+		{
+			name: "overlapping transposed",
+			i:    0, j: 1,
+			src: func(m *Dense) Matrix { return m.Slice(0, 4, 0, 4).T() },
+		},
+		{
+			name: "overlapping column",
+			i:    0, j: 4,
+			src: func(m *Dense) Matrix { return m.ColView(1) },
+		},
+		{
+			name: "overlapping row",
+			i:    0, j: 0,
+			src: func(m *Dense) Matrix { return m.RowView(3).T() },
+		},
+		{
+			name: "identical",
+			i:    0, j: 0,
+			src: func(m *Dense) Matrix { return m },
+		},
+	} {
+		m := newM()
+		src := test.src(m)
+		want := newM()
+		r, c := src.Dims()
+		srcCopy := DenseCopyOf(src)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				want.Set(test.i+i, test.j+j, srcCopy.At(i, j))
+			}
+		}
+		m.SetSlice(test.i, test.j, src)
+		if !Equal(m, want) {
+			t.Errorf("unexpected result for %s:\ngot:\n%v\nwant:\n%v", test.name, Formatted(m), Formatted(want))
+		}
+	}
+
+	m := newM()
+	for _, idx := range [][2]int{{-1, 0}, {0, -1}, {3, 0}, {0, 4}} {
+		panicked, message := panics(func() { m.SetSlice(idx[0], idx[1], NewDense(2, 2, nil)) })
+		if !panicked || message != ErrIndexOutOfRange.Error() {
+			t.Errorf("expected ErrIndexOutOfRange for SetSlice(%d, %d), got %q", idx[0], idx[1], message)
+		}
+	}
+}
+
+func TestDenseSwapRowsCols(t *testing.T) {
+	t.Parallel()
+	// The matrix is a 3×3 view into a padded backing slice; padding
+	// elements equal -1 and must not be modified.
+	newMat := func() *Dense {
+		return &Dense{
+			mat: blas64.General{
+				Rows:   3,
+				Cols:   3,
+				Stride: 4,
+				Data: []float64{
+					1, 2, 3, -1,
+					4, 5, 6, -1,
+					7, 8, 9, -1,
+				},
+			},
+			capRows: 3,
+			capCols: 3,
+		}
+	}
+	for _, test := range []struct {
+		i, j int
+		cols bool
+		want *Dense
+	}{
+		{i: 0, j: 2, want: NewDense(3, 3, []float64{7, 8, 9, 4, 5, 6, 1, 2, 3})},
+		{i: 1, j: 0, want: NewDense(3, 3, []float64{4, 5, 6, 1, 2, 3, 7, 8, 9})},
+		{i: 1, j: 1, want: NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})},
+		{i: 0, j: 2, cols: true, want: NewDense(3, 3, []float64{3, 2, 1, 6, 5, 4, 9, 8, 7})},
+		{i: 2, j: 1, cols: true, want: NewDense(3, 3, []float64{1, 3, 2, 4, 6, 5, 7, 9, 8})},
+		{i: 0, j: 0, cols: true, want: NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})},
+	} {
+		m := newMat()
+		if test.cols {
+			m.SwapCols(test.i, test.j)
+		} else {
+			m.SwapRows(test.i, test.j)
+		}
+		if !Equal(m, test.want) {
+			t.Errorf("unexpected result swapping %d and %d (cols=%t):\ngot:\n%v\nwant:\n%v",
+				test.i, test.j, test.cols, Formatted(m), Formatted(test.want))
+		}
+		for k := 3; k < len(m.mat.Data); k += m.mat.Stride {
+			if m.mat.Data[k] != -1 {
+				t.Errorf("padding modified swapping %d and %d (cols=%t)", test.i, test.j, test.cols)
+			}
+		}
+	}
+
+	for _, idx := range [][2]int{{-1, 0}, {0, 3}, {3, 3}} {
+		m := newMat()
+		panicked, message := panics(func() { m.SwapRows(idx[0], idx[1]) })
+		if !panicked || message != ErrIndexOutOfRange.Error() {
+			t.Errorf("expected ErrIndexOutOfRange for SwapRows(%d, %d)", idx[0], idx[1])
+		}
+		panicked, message = panics(func() { m.SwapCols(idx[0], idx[1]) })
+		if !panicked || message != ErrIndexOutOfRange.Error() {
+			t.Errorf("expected ErrIndexOutOfRange for SwapCols(%d, %d)", idx[0], idx[1])
+		}
+	}
+}
+
+func TestDensePermuteRows(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 7} {
+		a := NewDense(n, 3, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		p := rnd.Perm(n)
+		var perm Dense
+		perm.Permutation(n, p)
+
+		for _, inverse := range []bool{false, true} {
+			var want Dense
+			if inverse {
+				want.Mul(perm.T(), a)
+			} else {
+				want.Mul(&perm, a)
+			}
+			got := DenseCopyOf(a)
+			got.PermuteRows(p, inverse)
+			if !Equal(got, &want) {
+				t.Errorf("unexpected result for n=%d p=%v inverse=%t", n, p, inverse)
+			}
+			got.PermuteRows(p, !inverse)
+			if !Equal(got, a) {
+				t.Errorf("permutation round trip failed for n=%d p=%v inverse=%t", n, p, inverse)
+			}
+		}
+	}
+
+	m := NewDense(3, 2, nil)
+	panicked, message := panics(func() { m.PermuteRows([]int{2, 0, 2}, false) })
+	if !panicked || message != badPermutation {
+		t.Errorf("expected panic for invalid permutation, got %q", message)
+	}
+}
+
+func TestDenseOneHot(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		labels  []int
+		classes int
+		want    *Dense
+	}{
+		{
+			labels:  []int{0, 2, 1, 2},
+			classes: 3,
+			want: NewDense(4, 3, []float64{
+				1, 0, 0,
+				0, 0, 1,
+				0, 1, 0,
+				0, 0, 1,
+			}),
+		},
+		{
+			labels:  []int{1, 1},
+			classes: 4,
+			want: NewDense(2, 4, []float64{
+				0, 1, 0, 0,
+				0, 1, 0, 0,
+			}),
+		},
+		{
+			labels:  []int{3, 0},
+			classes: 0,
+			want: NewDense(2, 4, []float64{
+				0, 0, 0, 1,
+				1, 0, 0, 0,
+			}),
+		},
+	} {
+		var m Dense
+		m.OneHot(test.labels, test.classes)
+		if !Equal(&m, test.want) {
+			t.Errorf("unexpected result for labels=%v classes=%d:\ngot:\n%v\nwant:\n%v",
+				test.labels, test.classes, Formatted(&m), Formatted(test.want))
+		}
+
+		// A non-empty receiver must be fully overwritten.
+		r, c := test.want.Dims()
+		m2 := NewDense(r, c, nil)
+		for i := range m2.mat.Data {
+			m2.mat.Data[i] = 5
+		}
+		m2.OneHot(test.labels, test.classes)
+		if !Equal(m2, test.want) {
+			t.Errorf("unexpected result with non-empty receiver for labels=%v", test.labels)
+		}
+	}
+
+	for _, test := range []struct {
+		labels  []int
+		classes int
+	}{
+		{labels: []int{0, 3}, classes: 3},
+		{labels: []int{-1, 0}, classes: 3},
+		{labels: []int{-1, 0}, classes: 0},
+	} {
+		var m Dense
+		panicked, message := panics(func() { m.OneHot(test.labels, test.classes) })
+		if !panicked || message != ErrIndexOutOfRange.Error() {
+			t.Errorf("expected ErrIndexOutOfRange for labels=%v classes=%d", test.labels, test.classes)
+		}
+	}
+}
+
+func TestDenseReshape(t *testing.T) {
+	t.Parallel()
+	data := []float64{1, 2, 3, 4, 5, 6}
+	m := NewDense(2, 3, data)
+	m.Reshape(3, 2)
+	want := NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	if !Equal(m, want) {
+		t.Errorf("unexpected reshape result:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+	if r, c := m.Caps(); r != 3 || c != 2 {
+		t.Errorf("unexpected caps: got %d×%d want 3×2", r, c)
+	}
+	m.Set(0, 1, -2)
+	if data[1] != -2 {
+		t.Errorf("contiguous reshape did not share backing data")
+	}
+	m.Reshape(1, 6)
+	want = NewDense(1, 6, []float64{1, -2, 3, 4, 5, 6})
+	if !Equal(m, want) {
+		t.Errorf("unexpected reshape result:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+
+	// A strided view is compacted into new storage.
+	base := NewDense(3, 4, []float64{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	})
+	v := base.Slice(0, 3, 1, 3).(*Dense)
+	v.Reshape(2, 3)
+	want = NewDense(2, 3, []float64{2, 3, 6, 7, 10, 11})
+	if !Equal(v, want) {
+		t.Errorf("unexpected strided reshape result:\ngot:\n%v\nwant:\n%v", Formatted(v), Formatted(want))
+	}
+	v.Set(0, 0, -1)
+	if base.At(0, 1) != 2 {
+		t.Errorf("strided reshape shares backing data")
+	}
+
+	for _, dims := range [][2]int{{4, 2}, {6, 0}, {-1, -6}} {
+		m := NewDense(2, 3, nil)
+		panicked, _ := panics(func() { m.Reshape(dims[0], dims[1]) })
+		if !panicked {
+			t.Errorf("expected panic for Reshape(%d, %d)", dims[0], dims[1])
+		}
+	}
+	m = NewDense(2, 3, nil)
+	panicked, message := panics(func() { m.Reshape(4, 2) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched element count")
+	}
+}
+
 func TestDenseZero(t *testing.T) {
 	t.Parallel()
 	// Elements that equal 1 should be set to zero, elements that equal -1
@@ -419,6 +758,68 @@ func TestDenseDiagView(t *testing.T) {
 	}
 }
 
+func TestDenseSlice(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		i, k, j, l int
+		want       error
+	}{
+		{i: 0, k: 4, j: 0, l: 5},
+		{i: 1, k: 3, j: 2, l: 5},
+		{i: 3, k: 4, j: 4, l: 5},
+		{i: 1, k: 1, j: 0, l: 5, want: ErrZeroLength},
+		{i: 0, k: 4, j: 2, l: 2, want: ErrZeroLength},
+		{i: 4, k: 4, j: 0, l: 5, want: ErrZeroLength},
+		{i: 0, k: 5, j: 0, l: 5, want: ErrIndexOutOfRange},
+		{i: -1, k: 2, j: 0, l: 5, want: ErrIndexOutOfRange},
+		{i: 2, k: 1, j: 0, l: 5, want: ErrIndexOutOfRange},
+	} {
+		m := NewDense(4, 5, nil)
+		for r := 0; r < 4; r++ {
+			for c := 0; c < 5; c++ {
+				m.Set(r, c, float64(10*r+c))
+			}
+		}
+
+		var s *Dense
+		var got error
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					got = r.(error)
+				}
+			}()
+			s = m.Slice(test.i, test.k, test.j, test.l).(*Dense)
+		}()
+		if got != test.want {
+			t.Errorf("test %d: unexpected panic: got: %v want: %v", i, got, test.want)
+			continue
+		}
+		if test.want != nil {
+			continue
+		}
+
+		r, c := s.Dims()
+		if r != test.k-test.i || c != test.l-test.j {
+			t.Errorf("test %d: unexpected dimensions: got: %d×%d want: %d×%d", i, r, c, test.k-test.i, test.l-test.j)
+		}
+		if s.RawMatrix().Stride != m.RawMatrix().Stride {
+			t.Errorf("test %d: unexpected stride: got: %d want: %d", i, s.RawMatrix().Stride, m.RawMatrix().Stride)
+		}
+		for si := 0; si < r; si++ {
+			for sj := 0; sj < c; sj++ {
+				if s.At(si, sj) != m.At(test.i+si, test.j+sj) {
+					t.Errorf("test %d: unexpected value at (%d, %d)", i, si, sj)
+				}
+			}
+		}
+		s.Set(0, 0, -1)
+		if m.At(test.i, test.j) != -1 {
+			t.Errorf("test %d: write to slice not reflected in receiver", i)
+		}
+	}
+}
+
 func TestDenseGrow(t *testing.T) {
 	t.Parallel()
 	m := &Dense{}
@@ -959,6 +1360,269 @@ func randDense(size int, rho float64, src rand.Source) (*Dense, error) {
 	return d, nil
 }
 
+func TestDenseMulAccum(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	randMat := func(r, c int) *Dense {
+		m := NewDense(r, c, nil)
+		for i := range m.mat.Data {
+			m.mat.Data[i] = rnd.NormFloat64()
+		}
+		return m
+	}
+	want := func(m *Dense, alpha float64, a, b Matrix) *Dense {
+		var w Dense
+		w.Mul(a, b)
+		w.Scale(alpha, &w)
+		w.Add(&w, m)
+		return &w
+	}
+
+	a := randMat(3, 4)
+	b := randMat(4, 2)
+	for k, test := range []struct {
+		a, b Matrix
+	}{
+		{a: a, b: b},
+		{a: randMat(4, 3).T(), b: b},
+		{a: a, b: randMat(2, 4).T()},
+		{a: asBasicMatrix(a), b: b},
+		{a: a, b: asBasicMatrix(b)},
+	} {
+		for _, alpha := range []float64{0, 1, -2.5} {
+			m := randMat(3, 2)
+			w := want(m, alpha, test.a, test.b)
+			m.MulAccum(alpha, test.a, test.b)
+			if !EqualApprox(m, w, 1e-14) {
+				t.Errorf("test %d alpha=%v: unexpected result:\ngot:\n%v\nwant:\n%v", k, alpha, Formatted(m), Formatted(w))
+			}
+		}
+	}
+
+	// Aliased receiver.
+	sq := randMat(3, 3)
+	other := randMat(3, 3)
+	for k, alias := range []func(m *Dense) (a, b Matrix){
+		func(m *Dense) (a, b Matrix) { return m, other },
+		func(m *Dense) (a, b Matrix) { return other, m },
+		func(m *Dense) (a, b Matrix) { return m, m },
+		func(m *Dense) (a, b Matrix) { return m.T(), other },
+	} {
+		m := DenseCopyOf(sq)
+		a, b := alias(m)
+		w := want(DenseCopyOf(sq), 1.5, a, b)
+		m.MulAccum(1.5, a, b)
+		if !EqualApprox(m, w, 1e-14) {
+			t.Errorf("alias test %d: unexpected result:\ngot:\n%v\nwant:\n%v", k, Formatted(m), Formatted(w))
+		}
+	}
+
+	for _, m := range []*Dense{{}, NewDense(2, 2, nil), NewDense(3, 3, nil)} {
+		if panicked, _ := panics(func() { m.MulAccum(1, a, b) }); !panicked {
+			t.Errorf("expected panic for receiver with dims %d×%d", m.mat.Rows, m.mat.Cols)
+		}
+	}
+	if panicked, _ := panics(func() { NewDense(3, 2, nil).MulAccum(1, a, a) }); !panicked {
+		t.Error("expected panic for mismatched inner dimension")
+	}
+}
+
+func TestDenseDistanceMatrix(t *testing.T) {
+	t.Parallel()
+	a := NewDense(3, 2, []float64{
+		0, 0,
+		3, 4,
+		6, 8,
+	})
+	want := NewDense(3, 3, []float64{
+		0, 5, 10,
+		5, 0, 5,
+		10, 5, 0,
+	})
+	for i, test := range []struct {
+		a         Matrix
+		symmetric bool
+	}{
+		{a: a, symmetric: false},
+		{a: a, symmetric: true},
+		{a: asBasicMatrix(a), symmetric: true},
+		{a: DenseCopyOf(a.T()).T(), symmetric: false},
+	} {
+		var calls int
+		metric := func(x, y Vector) float64 {
+			calls++
+			return EuclideanDistance(x, y)
+		}
+		var m Dense
+		m.DistanceMatrix(test.a, metric, test.symmetric)
+		if !Equal(&m, want) {
+			t.Errorf("test %d: unexpected distance matrix:\ngot:\n%v\nwant:\n%v", i, Formatted(&m), Formatted(want))
+		}
+		wantCalls := 9
+		if test.symmetric {
+			wantCalls = 6
+		}
+		if calls != wantCalls {
+			t.Errorf("test %d: unexpected number of metric calls: got: %d want: %d", i, calls, wantCalls)
+		}
+	}
+
+	// Asymmetric metric.
+	var m Dense
+	m.DistanceMatrix(a, func(x, y Vector) float64 { return y.AtVec(0) - x.AtVec(0) }, false)
+	wantAsym := NewDense(3, 3, []float64{
+		0, 3, 6,
+		-3, 0, 3,
+		-6, -3, 0,
+	})
+	if !Equal(&m, wantAsym) {
+		t.Errorf("unexpected asymmetric distance matrix:\ngot:\n%v\nwant:\n%v", Formatted(&m), Formatted(wantAsym))
+	}
+
+	if panicked, _ := panics(func() { NewDense(2, 2, nil).DistanceMatrix(a, EuclideanDistance, true) }); !panicked {
+		t.Error("expected panic for receiver size mismatch")
+	}
+}
+
+func TestDenseRandProject(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, d, k int
+	}{
+		{n: 1, d: 1, k: 1},
+		{n: 5, d: 3, k: 2},
+		{n: 4, d: 10, k: 6},
+	} {
+		a := NewDense(test.n, test.d, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+
+		const seed = 7
+		r := NewDense(test.d, test.k, nil)
+		src := rand.New(rand.NewSource(seed))
+		for i := range r.mat.Data {
+			r.mat.Data[i] = src.NormFloat64()
+		}
+		var want Dense
+		want.Mul(a, r)
+		want.Scale(1/math.Sqrt(float64(test.k)), &want)
+
+		var got Dense
+		got.RandProject(a, test.k, rand.New(rand.NewSource(seed)).NormFloat64)
+		if !EqualApprox(&got, &want, 1e-14) {
+			t.Errorf("unexpected projection for n=%d d=%d k=%d:\ngot:\n%v\nwant:\n%v",
+				test.n, test.d, test.k, Formatted(&got), Formatted(&want))
+		}
+	}
+
+	panicked, message := panics(func() {
+		var m Dense
+		m.RandProject(NewDense(2, 2, nil), 0, nil)
+	})
+	if !panicked || message != ErrZeroLength.Error() {
+		t.Errorf("expected ErrZeroLength for k=0, got %q", message)
+	}
+	panicked, message = panics(func() {
+		var m Dense
+		m.RandProject(NewDense(2, 2, nil), 1, nil)
+	})
+	if !panicked || message != "mat: nil sampler" {
+		t.Errorf("expected panic for nil sampler, got %q", message)
+	}
+}
+
+func TestDenseStandardize(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		a    Matrix
+		want *Dense
+		mean []float64
+		std  []float64
+	}{
+		{
+			a: NewDense(3, 3, []float64{
+				1, 2, 5,
+				3, 6, 5,
+				5, 7, 5,
+			}),
+			want: NewDense(3, 3, []float64{
+				-1, -3 / math.Sqrt(7), 0,
+				0, 1 / math.Sqrt(7), 0,
+				1, 2 / math.Sqrt(7), 0,
+			}),
+			mean: []float64{3, 5, 5},
+			std:  []float64{2, math.Sqrt(7), 0},
+		},
+		{
+			a: NewDense(3, 2, []float64{
+				1, 3,
+				2, 6,
+				5, 7,
+			}).T(),
+			want: NewDense(2, 3, []float64{
+				-1 / math.Sqrt2, -1 / math.Sqrt2, -1 / math.Sqrt2,
+				1 / math.Sqrt2, 1 / math.Sqrt2, 1 / math.Sqrt2,
+			}),
+			mean: []float64{2, 4, 6},
+			std:  []float64{math.Sqrt2, math.Sqrt(8), math.Sqrt2},
+		},
+		{
+			a:    NewDense(1, 2, []float64{4, -1}),
+			want: NewDense(1, 2, []float64{0, 0}),
+			mean: []float64{4, -1},
+			std:  []float64{0, 0},
+		},
+	} {
+		var m Dense
+		mean, std := m.Standardize(test.a)
+		if !EqualApprox(&m, test.want, 1e-14) {
+			t.Errorf("unexpected result:\ngot:\n%v\nwant:\n%v", Formatted(&m), Formatted(test.want))
+		}
+		if !EqualApprox(mean, NewVecDense(len(test.mean), test.mean), 1e-14) {
+			t.Errorf("unexpected mean: got %v want %v", Formatted(mean.T()), test.mean)
+		}
+		if !EqualApprox(std, NewVecDense(len(test.std), test.std), 1e-14) {
+			t.Errorf("unexpected std: got %v want %v", Formatted(std.T()), test.std)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(7, 3, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = 10*rnd.NormFloat64() + 3
+	}
+	a.SetCol(1, []float64{2, 2, 2, 2, 2, 2, 2})
+	orig := DenseCopyOf(a)
+	mean, std := a.Standardize(a)
+	for j := 0; j < 3; j++ {
+		col := Col(nil, j, a)
+		if m := floats.Sum(col) / 7; math.Abs(m) > 1e-14 {
+			t.Errorf("column %d not centered: mean=%v", j, m)
+		}
+		wantNorm := math.Sqrt(6)
+		if j == 1 {
+			wantNorm = 0
+		}
+		if n := floats.Norm(col, 2); math.Abs(n-wantNorm) > 1e-14 {
+			t.Errorf("column %d not unit variance: norm=%v want %v", j, n, wantNorm)
+		}
+	}
+	for i := 0; i < 7; i++ {
+		for j := 0; j < 3; j++ {
+			got := a.At(i, j)
+			if s := std.AtVec(j); s != 0 {
+				got *= s
+			}
+			got += mean.AtVec(j)
+			if math.Abs(got-orig.At(i, j)) > 1e-12 {
+				t.Errorf("inverse transform mismatch at (%d,%d): got %v want %v", i, j, got, orig.At(i, j))
+			}
+		}
+	}
+}
+
 func TestDenseExp(t *testing.T) {
 	t.Parallel()
 	for i, test := range []struct {
@@ -1218,6 +1882,32 @@ func TestDenseScale(t *testing.T) {
 		}
 		testOneInput(t, "Scale", &Dense{}, method, denseComparison, isAnyType, isAnySize, 1e-14)
 	}
+
+	// In-place scaling of contiguous and sliced matrices.
+	for _, f := range []float64{0, 1, -2} {
+		m := NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+		m.Scale(f, m)
+		want := NewDense(2, 3, []float64{f, 2 * f, 3 * f, 4 * f, 5 * f, 6 * f})
+		if !Equal(m, want) {
+			t.Errorf("f=%v: unexpected in-place scale:\ngot:\n%v\nwant:\n%v", f, Formatted(m), Formatted(want))
+		}
+
+		parent := NewDense(3, 4, []float64{
+			9, 1, 2, 9,
+			9, 3, 4, 9,
+			9, 5, 6, 9,
+		})
+		view := parent.Slice(0, 3, 1, 3).(*Dense)
+		view.Scale(f, view)
+		want = NewDense(3, 4, []float64{
+			9, f, 2 * f, 9,
+			9, 3 * f, 4 * f, 9,
+			9, 5 * f, 6 * f, 9,
+		})
+		if !Equal(parent, want) {
+			t.Errorf("f=%v: unexpected in-place scale of view:\ngot:\n%v\nwant:\n%v", f, Formatted(parent), Formatted(want))
+		}
+	}
 }
 
 func TestDensePowN(t *testing.T) {
@@ -1250,6 +1940,12 @@ func TestDensePowN(t *testing.T) {
 			if !Equal(&got, &want) {
 				t.Errorf("unexpected result for iterative Pow test %d", i)
 			}
+
+			alias := NewDense(flatten(test.a))
+			alias.Pow(alias, n)
+			if !Equal(alias, &want) {
+				t.Errorf("unexpected result for aliased Pow test %d", i)
+			}
 		}
 	}
 }
@@ -1542,6 +2238,509 @@ func TestDenseApply(t *testing.T) {
 	}
 }
 
+func TestDenseApplyErr(t *testing.T) {
+	t.Parallel()
+	errNegative := errors.New("negative value")
+	logFn := func(_, _ int, v float64) (float64, error) {
+		if v < 0 {
+			return 0, errNegative
+		}
+		return math.Log(v), nil
+	}
+
+	for _, test := range []struct {
+		a      Matrix
+		errI   int
+		errJ   int
+		want   *Dense
+		failed bool
+	}{
+		{
+			a:    NewDense(2, 2, []float64{1, math.E, 1, 1}),
+			want: NewDense(2, 2, []float64{0, 1, 0, 0}),
+		},
+		{
+			a:    asBasicMatrix(NewDense(2, 2, []float64{1, math.E, 1, 1})),
+			want: NewDense(2, 2, []float64{0, 1, 0, 0}),
+		},
+		{
+			a:      NewDense(2, 3, []float64{1, math.E, 1, 1, -1, 1}),
+			errI:   1,
+			errJ:   1,
+			want:   NewDense(2, 3, []float64{0, 1, 0, 0, 7, 7}),
+			failed: true,
+		},
+		{
+			a:      NewDense(3, 2, []float64{1, -1, math.E, 1, 1, 1}).T(),
+			errI:   1,
+			errJ:   0,
+			want:   NewDense(2, 3, []float64{0, 1, 0, 7, 7, 7}),
+			failed: true,
+		},
+		{
+			a:      asBasicMatrix(NewDense(2, 3, []float64{1, math.E, 1, 1, -1, 1})),
+			errI:   1,
+			errJ:   1,
+			want:   NewDense(2, 3, []float64{0, 1, 0, 0, 7, 7}),
+			failed: true,
+		},
+	} {
+		r, c := test.a.Dims()
+		m := NewDense(r, c, nil)
+		for i := range m.mat.Data {
+			m.mat.Data[i] = 7
+		}
+		err := m.ApplyErr(logFn, test.a)
+		if !test.failed {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		} else {
+			e, ok := err.(ApplyError)
+			if !ok {
+				t.Errorf("unexpected error type %T", err)
+			} else if e.I != test.errI || e.J != test.errJ || e.Unwrap() != errNegative {
+				t.Errorf("unexpected error: got %v want failure at (%d, %d)", err, test.errI, test.errJ)
+			}
+		}
+		if !EqualApprox(m, test.want, 1e-14) {
+			t.Errorf("unexpected result:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(test.want))
+		}
+	}
+
+	// In-place transposed application.
+	a := NewDense(2, 2, []float64{1, math.E, -1, 1})
+	err := a.ApplyErr(logFn, a.T())
+	if err == nil {
+		t.Fatal("expected error for in-place transposed application")
+	}
+	want := NewDense(2, 2, []float64{0, math.E, -1, 1})
+	if !EqualApprox(a, want, 1e-14) {
+		t.Errorf("unexpected in-place transposed result:\ngot:\n%v\nwant:\n%v", Formatted(a), Formatted(want))
+	}
+	a = NewDense(2, 2, []float64{1, math.E, 1, 1})
+	if err := a.ApplyErr(logFn, a.T()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want = NewDense(2, 2, []float64{0, 0, 1, 0})
+	if !EqualApprox(a, want, 1e-14) {
+		t.Errorf("unexpected in-place transposed result:\ngot:\n%v\nwant:\n%v", Formatted(a), Formatted(want))
+	}
+}
+
+func TestDenseThreshold(t *testing.T) {
+	t.Parallel()
+	a := NewDense(2, 3, []float64{
+		0.5, -2, 0,
+		-0.1, 1, 3,
+	})
+	for _, test := range []struct {
+		a     Matrix
+		tol   float64
+		want  *Dense
+		wantN int
+	}{
+		{
+			a:   a,
+			tol: 0.5,
+			want: NewDense(2, 3, []float64{
+				0, -2, 0,
+				0, 1, 3,
+			}),
+			wantN: 2,
+		},
+		{
+			a:   a.T(),
+			tol: 1,
+			want: NewDense(3, 2, []float64{
+				0, 0,
+				-2, 0,
+				0, 3,
+			}),
+			wantN: 3,
+		},
+		{
+			a:     asBasicMatrix(a),
+			tol:   0,
+			want:  a,
+			wantN: 0,
+		},
+	} {
+		var m Dense
+		n := m.Threshold(test.a, test.tol)
+		if n != test.wantN {
+			t.Errorf("unexpected count for tol=%v: got %d want %d", test.tol, n, test.wantN)
+		}
+		if !Equal(&m, test.want) {
+			t.Errorf("unexpected result for tol=%v:\ngot:\n%v\nwant:\n%v", test.tol, Formatted(&m), Formatted(test.want))
+		}
+	}
+
+	// In-place operation.
+	m := DenseCopyOf(a)
+	if n := m.Threshold(m, 0.5); n != 2 {
+		t.Errorf("unexpected in-place count: got %d want 2", n)
+	}
+	want := NewDense(2, 3, []float64{0, -2, 0, 0, 1, 3})
+	if !Equal(m, want) {
+		t.Errorf("unexpected in-place result:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+	sq := NewDense(2, 2, []float64{1, 0.2, 3, 4})
+	sq.Threshold(sq.T(), 0.5)
+	want = NewDense(2, 2, []float64{1, 3, 0, 4})
+	if !Equal(sq, want) {
+		t.Errorf("unexpected in-place transposed result:\ngot:\n%v\nwant:\n%v", Formatted(sq), Formatted(want))
+	}
+}
+
+func TestDenseBroadcastVec(t *testing.T) {
+	t.Parallel()
+	a := NewDense(2, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+	rowVec := NewVecDense(3, []float64{1, -1, 2})
+	colVec := NewVecDense(2, []float64{10, 20})
+	for _, test := range []struct {
+		name string
+		fn   func(m *Dense, a Matrix, v Vector)
+		a    Matrix
+		v    Vector
+		want *Dense
+	}{
+		{
+			name: "AddRowVec",
+			fn:   (*Dense).AddRowVec,
+			a:    a,
+			v:    rowVec,
+			want: NewDense(2, 3, []float64{2, 1, 5, 5, 4, 8}),
+		},
+		{
+			name: "SubRowVec",
+			fn:   (*Dense).SubRowVec,
+			a:    a,
+			v:    rowVec,
+			want: NewDense(2, 3, []float64{0, 3, 1, 3, 6, 4}),
+		},
+		{
+			name: "SubRowVec",
+			fn:   (*Dense).SubRowVec,
+			a:    a.T(),
+			v:    colVec,
+			want: NewDense(3, 2, []float64{-9, -16, -8, -15, -7, -14}),
+		},
+		{
+			name: "AddColVec",
+			fn:   (*Dense).AddColVec,
+			a:    asBasicMatrix(a),
+			v:    &basicVector{m: []float64{10, 20}},
+			want: NewDense(2, 3, []float64{11, 12, 13, 24, 25, 26}),
+		},
+		{
+			name: "SubColVec",
+			fn:   (*Dense).SubColVec,
+			a:    a,
+			v:    colVec,
+			want: NewDense(2, 3, []float64{-9, -8, -7, -16, -15, -14}),
+		},
+		{
+			name: "SubColVec",
+			fn:   (*Dense).SubColVec,
+			a:    a.T(),
+			v:    rowVec,
+			want: NewDense(3, 2, []float64{0, 3, 3, 6, 1, 4}),
+		},
+	} {
+		var m Dense
+		test.fn(&m, test.a, test.v)
+		if !Equal(&m, test.want) {
+			t.Errorf("unexpected %s result:\ngot:\n%v\nwant:\n%v", test.name, Formatted(&m), Formatted(test.want))
+		}
+	}
+
+	// In-place operation.
+	m := DenseCopyOf(a)
+	m.SubRowVec(m, rowVec)
+	want := NewDense(2, 3, []float64{0, 3, 1, 3, 6, 4})
+	if !Equal(m, want) {
+		t.Errorf("unexpected in-place result:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+
+	// Centering with a vector that is a view of the receiver.
+	m = DenseCopyOf(a)
+	m.SubRowVec(m, m.RowView(0))
+	want = NewDense(2, 3, []float64{0, 0, 0, 3, 3, 3})
+	if !Equal(m, want) {
+		t.Errorf("unexpected result with aliased vector:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+	m = DenseCopyOf(a)
+	m.SubColVec(m, m.ColView(1))
+	want = NewDense(2, 3, []float64{-1, 0, 1, -1, 0, 1})
+	if !Equal(m, want) {
+		t.Errorf("unexpected result with aliased column vector:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+
+	sq := NewDense(2, 2, []float64{1, 2, 3, 4})
+	sq.AddColVec(sq.T(), NewVecDense(2, []float64{1, 2}))
+	want = NewDense(2, 2, []float64{2, 4, 4, 6})
+	if !Equal(sq, want) {
+		t.Errorf("unexpected in-place transposed result:\ngot:\n%v\nwant:\n%v", Formatted(sq), Formatted(want))
+	}
+
+	if ok, _ := panics(func() { new(Dense).SubRowVec(a, colVec) }); !ok {
+		t.Error("expected panic for row vector length mismatch")
+	}
+	if ok, _ := panics(func() { new(Dense).SubColVec(a, rowVec) }); !ok {
+		t.Error("expected panic for column vector length mismatch")
+	}
+}
+
+func TestDenseScaleRowsCols(t *testing.T) {
+	t.Parallel()
+	a := NewDense(2, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+	rowScale := NewVecDense(2, []float64{2, -1})
+	colScale := NewVecDense(3, []float64{1, 0.5, -2})
+	for _, test := range []struct {
+		name string
+		fn   func(m *Dense, a Matrix, v Vector)
+		a    Matrix
+		v    Vector
+		want *Dense
+	}{
+		{
+			name: "ScaleRows",
+			fn:   (*Dense).ScaleRows,
+			a:    a,
+			v:    rowScale,
+			want: NewDense(2, 3, []float64{2, 4, 6, -4, -5, -6}),
+		},
+		{
+			name: "ScaleRows",
+			fn:   (*Dense).ScaleRows,
+			a:    a.T(),
+			v:    colScale,
+			want: NewDense(3, 2, []float64{1, 4, 1, 2.5, -6, -12}),
+		},
+		{
+			name: "ScaleRows",
+			fn:   (*Dense).ScaleRows,
+			a:    asBasicMatrix(a),
+			v:    &basicVector{m: []float64{2, -1}},
+			want: NewDense(2, 3, []float64{2, 4, 6, -4, -5, -6}),
+		},
+		{
+			name: "ScaleCols",
+			fn:   (*Dense).ScaleCols,
+			a:    a,
+			v:    colScale,
+			want: NewDense(2, 3, []float64{1, 1, -6, 4, 2.5, -12}),
+		},
+		{
+			name: "ScaleCols",
+			fn:   (*Dense).ScaleCols,
+			a:    a.T(),
+			v:    rowScale,
+			want: NewDense(3, 2, []float64{2, -4, 4, -5, 6, -6}),
+		},
+	} {
+		var m Dense
+		test.fn(&m, test.a, test.v)
+		if !Equal(&m, test.want) {
+			t.Errorf("unexpected %s result:\ngot:\n%v\nwant:\n%v", test.name, Formatted(&m), Formatted(test.want))
+		}
+	}
+
+	// The result must match multiplication by the diagonal matrix.
+	var want, got Dense
+	want.Mul(NewDiagDense(2, []float64{2, -1}), a)
+	got.ScaleRows(a, rowScale)
+	if !EqualApprox(&got, &want, 1e-14) {
+		t.Errorf("ScaleRows does not match diagonal product:\ngot:\n%v\nwant:\n%v", Formatted(&got), Formatted(&want))
+	}
+	want.Mul(a, NewDiagDense(3, []float64{1, 0.5, -2}))
+	got.ScaleCols(a, colScale)
+	if !EqualApprox(&got, &want, 1e-14) {
+		t.Errorf("ScaleCols does not match diagonal product:\ngot:\n%v\nwant:\n%v", Formatted(&got), Formatted(&want))
+	}
+
+	// In-place operation.
+	m := DenseCopyOf(a)
+	m.ScaleCols(m, colScale)
+	wantInPlace := NewDense(2, 3, []float64{1, 1, -6, 4, 2.5, -12})
+	if !Equal(m, wantInPlace) {
+		t.Errorf("unexpected in-place result:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(wantInPlace))
+	}
+	m = DenseCopyOf(a)
+	m.ScaleRows(m, m.ColView(0))
+	wantInPlace = NewDense(2, 3, []float64{1, 2, 3, 16, 20, 24})
+	if !Equal(m, wantInPlace) {
+		t.Errorf("unexpected result with aliased vector:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(wantInPlace))
+	}
+	sq := NewDense(2, 2, []float64{1, 2, 3, 4})
+	sq.ScaleRows(sq.T(), NewVecDense(2, []float64{2, 3}))
+	wantInPlace = NewDense(2, 2, []float64{2, 6, 6, 12})
+	if !Equal(sq, wantInPlace) {
+		t.Errorf("unexpected in-place transposed result:\ngot:\n%v\nwant:\n%v", Formatted(sq), Formatted(wantInPlace))
+	}
+
+	if ok, _ := panics(func() { new(Dense).ScaleRows(a, colScale) }); !ok {
+		t.Error("expected panic for row scale length mismatch")
+	}
+	if ok, _ := panics(func() { new(Dense).ScaleCols(a, rowScale) }); !ok {
+		t.Error("expected panic for column scale length mismatch")
+	}
+}
+
+func TestDenseSoftThreshold(t *testing.T) {
+	t.Parallel()
+	a := NewDense(2, 3, []float64{
+		0.5, -2, 0,
+		-0.1, 1, 3,
+	})
+	for _, test := range []struct {
+		a      Matrix
+		lambda float64
+		want   *Dense
+	}{
+		{
+			a:      a,
+			lambda: 0.5,
+			want: NewDense(2, 3, []float64{
+				0, -1.5, 0,
+				0, 0.5, 2.5,
+			}),
+		},
+		{
+			a:      a.T(),
+			lambda: 1,
+			want: NewDense(3, 2, []float64{
+				0, 0,
+				-1, 0,
+				0, 2,
+			}),
+		},
+		{
+			a:      asBasicMatrix(a),
+			lambda: 0,
+			want:   a,
+		},
+	} {
+		var m Dense
+		m.SoftThreshold(test.a, test.lambda)
+		if !Equal(&m, test.want) {
+			t.Errorf("unexpected result for lambda=%v:\ngot:\n%v\nwant:\n%v", test.lambda, Formatted(&m), Formatted(test.want))
+		}
+	}
+
+	sq := NewDense(2, 2, []float64{1, 0.2, -3, 4})
+	sq.SoftThreshold(sq.T(), 0.5)
+	want := NewDense(2, 2, []float64{0.5, -2.5, 0, 3.5})
+	if !Equal(sq, want) {
+		t.Errorf("unexpected in-place transposed result:\ngot:\n%v\nwant:\n%v", Formatted(sq), Formatted(want))
+	}
+
+	nan := NewDense(1, 2, []float64{math.NaN(), -5})
+	nan.SoftThreshold(nan, 1)
+	if got := nan.RawRowView(0); !math.IsNaN(got[0]) || got[1] != -4 {
+		t.Errorf("unexpected result with NaN: got %v want [NaN -4]", got)
+	}
+
+	panicked, message := panics(func() { sq.SoftThreshold(sq, -1) })
+	if !panicked || message != "mat: negative soft threshold" {
+		t.Errorf("expected panic for negative lambda, got %q", message)
+	}
+}
+
+func TestDenseOrthonormalize(t *testing.T) {
+	t.Parallel()
+	const tol = 1e-10
+	for _, test := range []struct {
+		a    *Dense
+		rank int
+		zero []int
+	}{
+		{
+			a: NewDense(3, 3, []float64{
+				1, 1, 0,
+				1, 0, 1,
+				0, 1, 1,
+			}),
+			rank: 3,
+		},
+		{
+			a: NewDense(4, 3, []float64{
+				1, 2, 1,
+				2, 4, 0,
+				3, 6, 1,
+				4, 8, 0,
+			}),
+			rank: 2,
+			zero: []int{1},
+		},
+		{
+			a: NewDense(3, 4, []float64{
+				0, 1, 0, 1,
+				0, 0, 1, 1,
+				0, 0, 0, 1,
+			}),
+			rank: 3,
+			zero: []int{0},
+		},
+	} {
+		_, c := test.a.Dims()
+		for _, inPlace := range []bool{false, true} {
+			var m *Dense
+			var rank int
+			if inPlace {
+				m = DenseCopyOf(test.a)
+				rank = m.Orthonormalize(m, tol)
+			} else {
+				m = &Dense{}
+				rank = m.Orthonormalize(test.a, tol)
+			}
+			if rank != test.rank {
+				t.Errorf("unexpected rank: got %d want %d", rank, test.rank)
+			}
+
+			isZero := make([]bool, c)
+			for _, j := range test.zero {
+				isZero[j] = true
+			}
+			for j := 0; j < c; j++ {
+				qj := m.ColView(j)
+				if isZero[j] {
+					if Norm(qj, 2) != 0 {
+						t.Errorf("expected column %d to be zeroed", j)
+					}
+					continue
+				}
+				if n := Norm(qj, 2); math.Abs(n-1) > 1e-14 {
+					t.Errorf("column %d not unit norm: %v", j, n)
+				}
+				for k := 0; k < j; k++ {
+					if d := Dot(qj, m.ColView(k)); math.Abs(d) > 1e-14 {
+						t.Errorf("columns %d and %d not orthogonal: dot=%v", k, j, d)
+					}
+				}
+				// Column j of a must lie in the span of the first j+1 columns.
+				aj := test.a.ColView(j)
+				var res VecDense
+				res.CloneFromVec(aj)
+				for k := 0; k <= j; k++ {
+					qk := m.ColView(k)
+					res.AddScaledVec(&res, -Dot(qk, aj), qk)
+				}
+				if n := Norm(&res, 2); n > 1e-12 {
+					t.Errorf("column %d of a not in span of basis: residual=%v", j, n)
+				}
+			}
+		}
+	}
+}
+
 func TestDenseClone(t *testing.T) {
 	t.Parallel()
 	for i, test := range []struct {
@@ -1831,6 +3030,66 @@ func TestDenseOuter(t *testing.T) {
 	}
 }
 
+func TestDenseOuterDiff(t *testing.T) {
+	t.Parallel()
+	x := NewVecDense(3, []float64{1, 4, -2})
+	y := &basicVector{m: []float64{3, 0}}
+	var m Dense
+	m.OuterDiff(x, y)
+	want := NewDense(3, 2, []float64{
+		-2, 1,
+		1, 4,
+		-5, -2,
+	})
+	if !Equal(&m, want) {
+		t.Errorf("unexpected OuterDiff result:\ngot:\n%v\nwant:\n%v", Formatted(&m), Formatted(want))
+	}
+	m.OuterSqDist(x, y)
+	want = NewDense(3, 2, []float64{
+		4, 1,
+		1, 16,
+		25, 4,
+	})
+	if !Equal(&m, want) {
+		t.Errorf("unexpected OuterSqDist result:\ngot:\n%v\nwant:\n%v", Formatted(&m), Formatted(want))
+	}
+
+	for _, square := range []bool{false, true} {
+		square := square
+		name := "OuterDiff"
+		if square {
+			name = "OuterSqDist"
+		}
+		method := func(receiver, x, y Matrix) {
+			type outerDiffer interface {
+				OuterDiff(x, y Vector)
+				OuterSqDist(x, y Vector)
+			}
+			m := receiver.(outerDiffer)
+			if square {
+				m.OuterSqDist(x.(Vector), y.(Vector))
+			} else {
+				m.OuterDiff(x.(Vector), y.(Vector))
+			}
+		}
+		denseComparison := func(receiver, x, y *Dense) {
+			r, _ := x.Dims()
+			c, _ := y.Dims()
+			receiver.reuseAsNonZeroed(r, c)
+			for i := 0; i < r; i++ {
+				for j := 0; j < c; j++ {
+					d := x.At(i, 0) - y.At(j, 0)
+					if square {
+						d *= d
+					}
+					receiver.Set(i, j, d)
+				}
+			}
+		}
+		testTwoInput(t, name, &Dense{}, method, denseComparison, legalTypesVectorVector, legalSizeVector, 1e-12)
+	}
+}
+
 func TestDenseInverse(t *testing.T) {
 	t.Parallel()
 	for i, test := range []struct {
@@ -1838,6 +3097,30 @@ func TestDenseInverse(t *testing.T) {
 		want Matrix // nil indicates that a is singular.
 		tol  float64
 	}{
+		{
+			a: NewSymDense(3, []float64{
+				4, 2, 0,
+				0, 5, 1,
+				0, 0, 3,
+			}),
+			want: NewDense(3, 3, []float64{
+				14.0 / 44, -6.0 / 44, 2.0 / 44,
+				-6.0 / 44, 12.0 / 44, -4.0 / 44,
+				2.0 / 44, -4.0 / 44, 16.0 / 44,
+			}),
+			tol: 1e-14,
+		},
+		{
+			a: NewSymDense(2, []float64{
+				1, 2,
+				0, 1,
+			}),
+			want: NewDense(2, 2, []float64{
+				-1.0 / 3, 2.0 / 3,
+				2.0 / 3, -1.0 / 3,
+			}),
+			tol: 1e-14,
+		},
 		{
 			a: NewDense(3, 3, []float64{
 				8, 1, 6,
@@ -83,7 +83,7 @@ func NewDiagDense(n int, data []float64) *DiagDense {
 		if n == 0 {
 			panic(ErrZeroLength)
 		}
-		panic("mat: negative dimension")
+		panic(ErrNegativeDimension)
 	}
 	if data == nil {
 		data = make([]float64, n)
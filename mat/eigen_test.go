@@ -167,6 +167,23 @@ func TestSymEigen(t *testing.T) {
 		if !floats.EqualApprox(es2.values, es.values, 1e-14) {
 			t.Errorf("Eigenvalue mismatch when no vectors computed")
 		}
+
+		// Check that refactorizing without vectors
+		// invalidates previously computed vectors.
+		es.Factorize(test.mat, false)
+		if panicked, _ := panics(func() { es.VectorsTo(&Dense{}) }); !panicked {
+			t.Errorf("expected panic for VectorsTo after refactorization without vectors")
+		}
+
+		// Check that refactorizing a matrix of a different size
+		// does not retain previously computed values.
+		small := NewSymDense(2, []float64{2, 1, 1, 2})
+		if !es.Factorize(small, false) {
+			t.Errorf("bad refactorization")
+		}
+		if got := es.Values(nil); !floats.EqualApprox(got, []float64{1, 3}, 1e-14) {
+			t.Errorf("unexpected eigenvalues after refactorization: got %v want [1 3]", got)
+		}
 	}
 
 	// Randomized tests
@@ -44,6 +44,21 @@ const (
 
 const stackTraceBufferSize = 1 << 20
 
+// ApplyError is the error returned by Dense.ApplyErr when the applied
+// function fails. It records the row and column of the element at which
+// the failure occurred.
+type ApplyError struct {
+	I, J int
+	Err  error
+}
+
+func (err ApplyError) Error() string {
+	return fmt.Sprintf("mat: apply failed at (%d, %d): %v", err.I, err.J, err.Err)
+}
+
+// Unwrap returns the error returned by the applied function.
+func (err ApplyError) Unwrap() error { return err.Err }
+
 // Maybe will recover a panic with a type mat.Error from fn, and return this error
 // as the Err field of an ErrorStack. The stack trace for the panicking function will be
 // recovered and placed in the StackTrace field. Any other error is re-panicked.
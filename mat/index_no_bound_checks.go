@@ -68,6 +68,70 @@ func (m *CDense) set(i, j int, v complex128) {
 	m.mat.Data[i*m.mat.Stride+j] = v
 }
 
+// At returns the element at row i.
+// It panics if i is out of bounds or if j is not zero.
+func (v *CVecDense) At(i, j int) complex128 {
+	if uint(i) >= uint(v.mat.N) {
+		panic(ErrRowAccess)
+	}
+	if j != 0 {
+		panic(ErrColAccess)
+	}
+	return v.at(i)
+}
+
+// AtVec returns the element at row i.
+// It panics if i is out of bounds.
+func (v *CVecDense) AtVec(i int) complex128 {
+	if uint(i) >= uint(v.mat.N) {
+		panic(ErrRowAccess)
+	}
+	return v.at(i)
+}
+
+func (v *CVecDense) at(i int) complex128 {
+	return v.mat.Data[i*v.mat.Inc]
+}
+
+// SetVec sets the element at row i to the value val.
+// It panics if i is out of bounds.
+func (v *CVecDense) SetVec(i int, val complex128) {
+	if uint(i) >= uint(v.mat.N) {
+		panic(ErrVectorAccess)
+	}
+	v.setVec(i, val)
+}
+
+func (v *CVecDense) setVec(i int, val complex128) {
+	v.mat.Data[i*v.mat.Inc] = val
+}
+
+// AtVec returns the element at position i.
+// It panics if i is out of bounds.
+func (v *VecDense32) AtVec(i int) float32 {
+	if uint(i) >= uint(v.mat.N) {
+		panic(ErrVectorAccess)
+	}
+	return v.at(i)
+}
+
+func (v *VecDense32) at(i int) float32 {
+	return v.mat.Data[i*v.mat.Inc]
+}
+
+// SetVec sets the element at position i to the value val.
+// It panics if i is out of bounds.
+func (v *VecDense32) SetVec(i int, val float32) {
+	if uint(i) >= uint(v.mat.N) {
+		panic(ErrVectorAccess)
+	}
+	v.setVec(i, val)
+}
+
+func (v *VecDense32) setVec(i int, val float32) {
+	v.mat.Data[i*v.mat.Inc] = val
+}
+
 // At returns the element at row i.
 // It panics if i is out of bounds or if j is not zero.
 func (v *VecDense) At(i, j int) float64 {
@@ -0,0 +1,376 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// ConjugateGradient solves the system of linear equations a * x = b for x
+// using the conjugate gradient method, where a is a symmetric positive
+// definite matrix. The matrix a is only accessed through VecDense.MulVec, so
+// it may be any Matrix type that is efficient to multiply by a vector.
+//
+// If x is not empty its contents are used as the initial guess, otherwise the
+// iteration starts from zero and x is resized to the length of b. Iteration
+// stops when the relative residual ‖b - a*x‖₂/‖b‖₂ is no greater than tol
+// or after maxIter iterations. ConjugateGradient returns the number of
+// iterations performed and the relative residual achieved.
+//
+// If precond is not nil, it is used as a preconditioner, and must place the
+// result of applying the inverse of a symmetric positive definite
+// approximation of a to r into dst. The dst passed to precond has the length
+// of b.
+//
+// ConjugateGradient panics with ErrShape if a is not square or the
+// dimensions of a, x and b do not match.
+func ConjugateGradient(x *VecDense, a Matrix, b Vector, tol float64, maxIter int, precond func(dst *VecDense, r Vector)) (iters int, resid float64) {
+	n, c := a.Dims()
+	if n != c || b.Len() != n {
+		panic(ErrShape)
+	}
+	if x.IsEmpty() {
+		x.ReuseAsVec(n)
+	} else if x.Len() != n {
+		panic(ErrShape)
+	}
+
+	bNorm := Norm(b, 2)
+	if bNorm == 0 {
+		x.Zero()
+		return 0, 0
+	}
+
+	r := NewVecDense(n, nil)
+	r.MulVec(a, x)
+	r.SubVec(b, r)
+	resid = Norm(r, 2) / bNorm
+
+	z := r
+	if precond != nil {
+		z = NewVecDense(n, nil)
+		precond(z, r)
+	}
+	p := NewVecDense(n, nil)
+	p.CopyVec(z)
+	ap := NewVecDense(n, nil)
+	rz := Dot(r, z)
+
+	for iters < maxIter && resid > tol {
+		ap.MulVec(a, p)
+		alpha := rz / Dot(p, ap)
+		x.AddScaledVec(x, alpha, p)
+		r.AddScaledVec(r, -alpha, ap)
+		iters++
+		resid = Norm(r, 2) / bNorm
+		if resid <= tol {
+			break
+		}
+
+		if precond != nil {
+			precond(z, r)
+		}
+		rzNext := Dot(r, z)
+		p.AddScaledVec(z, rzNext/rz, p)
+		rz = rzNext
+	}
+	return iters, resid
+}
+
+// GMRES solves the system of linear equations a * x = b for x using the
+// restarted generalized minimal residual method, GMRES(restart), where a is
+// a general square matrix. The matrix a is only accessed through
+// VecDense.MulVec, so it may be any Matrix type that is efficient to multiply
+// by a vector.
+//
+// Each cycle builds an orthonormal basis of a Krylov subspace of dimension at
+// most restart using modified Gram-Schmidt orthogonalization, and reduces
+// the resulting Hessenberg matrix with Givens rotations to solve the least
+// squares problem for the update to x. If restart is greater than the order
+// of a, the order of a is used.
+//
+// If x is not empty its contents are used as the initial guess, otherwise the
+// iteration starts from zero and x is resized to the length of b. Iteration
+// stops when the relative residual ‖b - a*x‖₂/‖b‖₂ is no greater than tol
+// or after maxIter matrix-vector products with the Krylov basis. GMRES
+// returns the number of iterations performed and the relative residual
+// achieved.
+//
+// GMRES panics with ErrShape if a is not square or the dimensions of a, x and
+// b do not match, and panics if restart is not positive.
+func GMRES(x *VecDense, a Matrix, b Vector, restart int, tol float64, maxIter int) (iters int, resid float64) {
+	n, c := a.Dims()
+	if n != c || b.Len() != n {
+		panic(ErrShape)
+	}
+	if restart <= 0 {
+		panic("mat: non-positive GMRES restart")
+	}
+	if x.IsEmpty() {
+		x.ReuseAsVec(n)
+	} else if x.Len() != n {
+		panic(ErrShape)
+	}
+
+	bNorm := Norm(b, 2)
+	if bNorm == 0 {
+		x.Zero()
+		return 0, 0
+	}
+
+	m := min(restart, n)
+	// The rows of v hold the Krylov basis vectors.
+	v := NewDense(m+1, n, nil)
+	h := NewDense(m+1, m, nil)
+	cs := make([]float64, m)
+	sn := make([]float64, m)
+	g := make([]float64, m+1)
+	y := make([]float64, m)
+	r := NewVecDense(n, nil)
+	var w, vi VecDense
+
+	for {
+		r.MulVec(a, x)
+		r.SubVec(b, r)
+		beta := Norm(r, 2)
+		resid = beta / bNorm
+		if resid <= tol || iters >= maxIter {
+			return iters, resid
+		}
+
+		h.Zero()
+		for i := range g {
+			g[i] = 0
+		}
+		g[0] = beta
+		vi.RowViewOf(v, 0)
+		vi.ScaleVec(1/beta, r)
+
+		var k int
+		for k < m && iters < maxIter {
+			j := k
+			iters++
+			k++
+
+			w.RowViewOf(v, j+1)
+			vi.RowViewOf(v, j)
+			w.MulVec(a, &vi)
+
+			// Modified Gram-Schmidt orthogonalization.
+			for i := 0; i <= j; i++ {
+				vi.RowViewOf(v, i)
+				hij := Dot(&w, &vi)
+				h.set(i, j, hij)
+				w.AddScaledVec(&w, -hij, &vi)
+			}
+			wNorm := Norm(&w, 2)
+			h.set(j+1, j, wNorm)
+
+			// Apply the previous rotations to the new column
+			// of the Hessenberg matrix.
+			for i := 0; i < j; i++ {
+				hi, hi1 := h.at(i, j), h.at(i+1, j)
+				h.set(i, j, cs[i]*hi+sn[i]*hi1)
+				h.set(i+1, j, -sn[i]*hi+cs[i]*hi1)
+			}
+
+			// Eliminate the subdiagonal element.
+			hjj, hj1 := h.at(j, j), h.at(j+1, j)
+			rho := math.Hypot(hjj, hj1)
+			cs[j], sn[j] = hjj/rho, hj1/rho
+			h.set(j, j, rho)
+			h.set(j+1, j, 0)
+			g[j+1] = -sn[j] * g[j]
+			g[j] *= cs[j]
+
+			if wNorm == 0 || math.Abs(g[j+1])/bNorm <= tol {
+				break
+			}
+			w.ScaleVec(1/wNorm, &w)
+		}
+
+		// Solve the upper triangular system h[:k,:k] * y = g[:k]
+		// and update x with the basis combination.
+		for i := k - 1; i >= 0; i-- {
+			s := g[i]
+			for l := i + 1; l < k; l++ {
+				s -= h.at(i, l) * y[l]
+			}
+			y[i] = s / h.at(i, i)
+		}
+		for i := 0; i < k; i++ {
+			vi.RowViewOf(v, i)
+			x.AddScaledVec(x, y[i], &vi)
+		}
+	}
+}
+
+// PowerIteration estimates the eigenvalue of a with the largest magnitude and
+// its eigenvector using the power method. The matrix a is only accessed
+// through VecDense.MulVec, so it may be any Matrix type that is efficient to
+// multiply by a vector.
+//
+// If x0 is not nil it is used as the starting vector, otherwise the iteration
+// starts from the vector with all elements equal. At each iteration the
+// current unit vector v is multiplied by a, the eigenvalue is estimated by
+// the Rayleigh quotient λ = vᵀ*a*v, and iteration stops when
+// ‖a*v - λ*v‖₂ <= tol*|λ|. PowerIteration returns the estimated eigenvalue,
+// the corresponding unit eigenvector and the number of updates of the
+// vector that were made. If convergence was not achieved within maxIter
+// updates, the returned iters is equal to maxIter.
+//
+// PowerIteration panics with ErrShape if a is not square or the length of x0
+// does not match the order of a, and panics if x0 is the zero vector.
+func PowerIteration(a Matrix, x0 Vector, tol float64, maxIter int) (lambda float64, v *VecDense, iters int) {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrShape)
+	}
+	v = NewVecDense(n, nil)
+	if x0 != nil {
+		if x0.Len() != n {
+			panic(ErrShape)
+		}
+		if v.UnitVec(x0) == 0 {
+			panic("mat: zero starting vector")
+		}
+	} else {
+		for i := range v.mat.Data {
+			v.mat.Data[i] = 1 / math.Sqrt(float64(n))
+		}
+	}
+
+	w := NewVecDense(n, nil)
+	r := NewVecDense(n, nil)
+	for ; iters < maxIter; iters++ {
+		w.MulVec(a, v)
+		lambda = Dot(v, w)
+		r.AddScaledVec(w, -lambda, v)
+		if Norm(r, 2) <= tol*math.Abs(lambda) {
+			return lambda, v, iters
+		}
+		if v.UnitVec(w) == 0 {
+			// The starting vector lies in the null space of a.
+			return 0, v, iters
+		}
+	}
+	w.MulVec(a, v)
+	return Dot(v, w), v, maxIter
+}
+
+// Jacobi solves the system of linear equations a * x = b for x by performing
+// iters steps of the Jacobi iteration
+//  x_{k+1} = x_k + D⁻¹ * (b - a*x_k),
+// where D is the diagonal of a. The iteration converges for any starting
+// point when a is strictly diagonally dominant. The matrix a is accessed
+// through VecDense.MulVec and At, so it may be any Matrix type.
+//
+// If x is not empty its contents are used as the initial guess, otherwise the
+// iteration starts from zero and x is resized to the length of b. Jacobi
+// returns the norm of the final residual, ‖b - a*x‖₂.
+//
+// Jacobi panics with ErrShape if a is not square or the dimensions of a, x
+// and b do not match, and panics if a diagonal element of a is zero.
+func Jacobi(x *VecDense, a Matrix, b Vector, iters int) float64 {
+	n := checkStationary(x, a, b)
+	d := stationaryDiag(a, n)
+	defer putFloats(d)
+
+	r := NewVecDense(n, nil)
+	for k := 0; k < iters; k++ {
+		r.MulVec(a, x)
+		r.SubVec(b, r)
+		for i, dii := range d {
+			x.setVec(i, x.at(i)+r.at(i)/dii)
+		}
+	}
+	r.MulVec(a, x)
+	r.SubVec(b, r)
+	return Norm(r, 2)
+}
+
+// SOR solves the system of linear equations a * x = b for x by performing
+// iters sweeps of successive over-relaxation. In each sweep the elements of
+// x are updated in order using the most recent values of the others,
+//  x[i] = (1-omega)*x[i] + omega/a[i,i] * (b[i] - Σ_{j≠i} a[i,j]*x[j]).
+// With omega equal to one this is the Gauss-Seidel iteration. The iteration
+// converges for any starting point when a is symmetric positive definite and
+// 0 < omega < 2, or when a is strictly diagonally dominant and omega is one.
+// The rows of a are read directly if a is a RawMatrixer, otherwise through
+// At.
+//
+// If x is not empty its contents are used as the initial guess, otherwise the
+// iteration starts from zero and x is resized to the length of b. SOR returns
+// the norm of the final residual, ‖b - a*x‖₂.
+//
+// SOR panics with ErrShape if a is not square or the dimensions of a, x and
+// b do not match, and panics if a diagonal element of a is zero or omega is
+// not in the open interval (0, 2).
+func SOR(x *VecDense, a Matrix, b Vector, omega float64, iters int) float64 {
+	if omega <= 0 || 2 <= omega {
+		panic("mat: SOR relaxation parameter out of range")
+	}
+	n := checkStationary(x, a, b)
+	d := stationaryDiag(a, n)
+	defer putFloats(d)
+
+	rm, isRaw := a.(RawMatrixer)
+	var amat blas64.General
+	if isRaw {
+		amat = rm.RawMatrix()
+	}
+	for k := 0; k < iters; k++ {
+		for i, dii := range d {
+			var sigma float64
+			if isRaw {
+				for j, aij := range amat.Data[i*amat.Stride : i*amat.Stride+n] {
+					sigma += aij * x.at(j)
+				}
+			} else {
+				for j := 0; j < n; j++ {
+					sigma += a.At(i, j) * x.at(j)
+				}
+			}
+			xi := x.at(i)
+			sigma -= dii * xi
+			x.setVec(i, (1-omega)*xi+omega*(b.AtVec(i)-sigma)/dii)
+		}
+	}
+	r := NewVecDense(n, nil)
+	r.MulVec(a, x)
+	r.SubVec(b, r)
+	return Norm(r, 2)
+}
+
+// checkStationary checks the dimensions of the arguments to a stationary
+// iteration, resizing x if it is empty, and returns the order of a.
+func checkStationary(x *VecDense, a Matrix, b Vector) int {
+	n, c := a.Dims()
+	if n != c || b.Len() != n {
+		panic(ErrShape)
+	}
+	if x.IsEmpty() {
+		x.ReuseAsVec(n)
+	} else if x.Len() != n {
+		panic(ErrShape)
+	}
+	return n
+}
+
+// stationaryDiag returns the diagonal of the n×n matrix a in a slice obtained
+// from getFloats. It panics if a diagonal element is zero.
+func stationaryDiag(a Matrix, n int) []float64 {
+	d := getFloats(n, false)
+	for i := range d {
+		d[i] = a.At(i, i)
+		if d[i] == 0 {
+			putFloats(d)
+			panic("mat: zero diagonal element")
+		}
+	}
+	return d
+}
@@ -0,0 +1,300 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// randSPD returns a random, diagonally weighted, n×n symmetric positive
+// definite matrix.
+func randSPD(n int, rnd *rand.Rand) *SymDense {
+	x := NewDense(n, n, nil)
+	for i := range x.mat.Data {
+		x.mat.Data[i] = rnd.NormFloat64()
+	}
+	var a SymDense
+	a.SymOuterK(1, x)
+	for i := 0; i < n; i++ {
+		a.SetSym(i, i, a.At(i, i)+float64(n))
+	}
+	return &a
+}
+
+func TestConjugateGradient(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20, 50} {
+		a := randSPD(n, rnd)
+		b := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			b.SetVec(i, rnd.NormFloat64())
+		}
+		var chol Cholesky
+		if !chol.Factorize(a) {
+			t.Fatalf("n=%d: test matrix not positive definite", n)
+		}
+		var want VecDense
+		err := chol.SolveVecTo(&want, b)
+		if err != nil {
+			t.Fatalf("n=%d: unexpected error from Cholesky solve: %v", n, err)
+		}
+
+		jacobi := func(dst *VecDense, r Vector) {
+			for i := 0; i < n; i++ {
+				dst.SetVec(i, r.AtVec(i)/a.At(i, i))
+			}
+		}
+		for _, precond := range []func(*VecDense, Vector){nil, jacobi} {
+			const tol = 1e-12
+			var x VecDense
+			iters, resid := ConjugateGradient(&x, a, b, tol, 10*n, precond)
+			if resid > tol {
+				t.Errorf("n=%d precond=%t: residual too large after %d iterations: %v", n, precond != nil, iters, resid)
+			}
+			if !EqualApprox(&x, &want, 1e-9) {
+				t.Errorf("n=%d precond=%t: unexpected solution:\ngot: %v\nwant:%v", n, precond != nil, x.RawVector().Data, want.RawVector().Data)
+			}
+
+			// Warm start from the solution.
+			iters, _ = ConjugateGradient(&x, a, b, 1e-6, 10*n, precond)
+			if iters != 0 {
+				t.Errorf("n=%d precond=%t: unexpected iterations from warm start: %d", n, precond != nil, iters)
+			}
+		}
+	}
+
+	// Iteration limit.
+	a := randSPD(20, rnd)
+	b := NewVecDense(20, nil)
+	b.SetVec(0, 1)
+	var x VecDense
+	iters, resid := ConjugateGradient(&x, a, b, 0, 2, nil)
+	if iters != 2 || resid == 0 {
+		t.Errorf("unexpected result with iteration limit: iters=%d resid=%v", iters, resid)
+	}
+
+	// Zero right hand side.
+	x.SetVec(0, 1)
+	iters, resid = ConjugateGradient(&x, a, NewVecDense(20, nil), 1e-10, 10, nil)
+	if iters != 0 || resid != 0 || Norm(&x, 2) != 0 {
+		t.Errorf("unexpected result for zero right hand side: iters=%d resid=%v", iters, resid)
+	}
+
+	if panicked, _ := panics(func() { ConjugateGradient(&VecDense{}, NewDense(2, 3, nil), NewVecDense(2, nil), 0, 1, nil) }); !panicked {
+		t.Error("expected panic for non-square matrix")
+	}
+	if panicked, _ := panics(func() { ConjugateGradient(NewVecDense(3, nil), a, b, 0, 1, nil) }); !panicked {
+		t.Error("expected panic for mismatched x")
+	}
+}
+
+func TestGMRES(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20, 50} {
+		a := NewDense(n, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		for i := 0; i < n; i++ {
+			a.Set(i, i, a.At(i, i)+2*math.Sqrt(float64(n)))
+		}
+		b := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			b.SetVec(i, rnd.NormFloat64())
+		}
+		var want VecDense
+		err := want.SolveVec(a, b)
+		if err != nil {
+			t.Fatalf("n=%d: unexpected error from direct solve: %v", n, err)
+		}
+
+		for _, restart := range []int{1, 5, n, 2 * n} {
+			const tol = 1e-12
+			var x VecDense
+			iters, resid := GMRES(&x, a, b, restart, tol, 100*n)
+			if resid > tol {
+				t.Errorf("n=%d restart=%d: residual too large after %d iterations: %v", n, restart, iters, resid)
+			}
+			if !EqualApprox(&x, &want, 1e-9) {
+				t.Errorf("n=%d restart=%d: unexpected solution:\ngot: %v\nwant:%v", n, restart, x.RawVector().Data, want.RawVector().Data)
+			}
+			if restart >= n && iters > n {
+				t.Errorf("n=%d restart=%d: unexpected iterations for full GMRES: %d", n, restart, iters)
+			}
+
+			// Warm start from the solution.
+			iters, _ = GMRES(&x, a, b, restart, 1e-6, 100*n)
+			if iters != 0 {
+				t.Errorf("n=%d restart=%d: unexpected iterations from warm start: %d", n, restart, iters)
+			}
+		}
+	}
+
+	// Iteration limit.
+	a := NewDense(3, 3, []float64{
+		4, 1, 0,
+		-1, 3, 1,
+		0, 2, 5,
+	})
+	b := NewVecDense(3, []float64{1, 2, 3})
+	var x VecDense
+	iters, resid := GMRES(&x, a, b, 3, 0, 2)
+	if iters != 2 || resid == 0 {
+		t.Errorf("unexpected result with iteration limit: iters=%d resid=%v", iters, resid)
+	}
+
+	if panicked, _ := panics(func() { GMRES(&VecDense{}, a, b, 0, 0, 1) }); !panicked {
+		t.Error("expected panic for non-positive restart")
+	}
+	if panicked, _ := panics(func() { GMRES(&VecDense{}, NewDense(3, 2, nil), b, 1, 0, 1) }); !panicked {
+		t.Error("expected panic for non-square matrix")
+	}
+}
+
+func TestPowerIteration(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a      Matrix
+		x0     Vector
+		lambda float64
+		vec    []float64
+	}{
+		{
+			a:      NewDiagDense(3, []float64{1, -5, 2}),
+			x0:     NewVecDense(3, []float64{1, 1, 1}),
+			lambda: -5,
+			vec:    []float64{0, 1, 0},
+		},
+		{
+			a: NewSymDense(2, []float64{
+				2, 1,
+				1, 2,
+			}),
+			lambda: 3,
+			vec:    []float64{1 / math.Sqrt2, 1 / math.Sqrt2},
+		},
+		{
+			// Column stochastic matrix with stationary distribution
+			// proportional to [2 1].
+			a: NewDense(2, 2, []float64{
+				0.75, 0.5,
+				0.25, 0.5,
+			}),
+			lambda: 1,
+			vec:    []float64{2 / math.Sqrt(5), 1 / math.Sqrt(5)},
+		},
+	} {
+		const tol = 1e-12
+		lambda, v, iters := PowerIteration(test.a, test.x0, tol, 1000)
+		if iters == 1000 {
+			t.Errorf("test %d: did not converge", i)
+		}
+		if math.Abs(lambda-test.lambda) > 1e-10 {
+			t.Errorf("test %d: unexpected eigenvalue: got: %v want: %v", i, lambda, test.lambda)
+		}
+		// Eigenvectors are only defined up to sign.
+		if v.AtVec(0)*test.vec[0] < 0 || v.AtVec(1)*test.vec[1] < 0 {
+			v.ScaleVec(-1, v)
+		}
+		if want := NewVecDense(len(test.vec), test.vec); !EqualApprox(v, want, 1e-10) {
+			t.Errorf("test %d: unexpected eigenvector: got: %v want: %v", i, v.RawVector().Data, test.vec)
+		}
+	}
+
+	// Non-convergence for a rotation.
+	rot := NewDense(2, 2, []float64{0, -1, 1, 0})
+	if _, _, iters := PowerIteration(rot, nil, 1e-12, 20); iters != 20 {
+		t.Errorf("unexpected convergence for rotation after %d iterations", iters)
+	}
+
+	if panicked, _ := panics(func() { PowerIteration(NewDense(2, 3, nil), nil, 0, 1) }); !panicked {
+		t.Error("expected panic for non-square matrix")
+	}
+	if panicked, _ := panics(func() { PowerIteration(rot, NewVecDense(2, nil), 0, 1) }); !panicked {
+		t.Error("expected panic for zero starting vector")
+	}
+}
+
+func TestJacobiSOR(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20} {
+		// Make a strictly diagonally dominant matrix.
+		a := NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			var sum float64
+			for j := 0; j < n; j++ {
+				v := rnd.NormFloat64()
+				a.Set(i, j, v)
+				sum += math.Abs(v)
+			}
+			a.Set(i, i, sum+1)
+		}
+		b := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			b.SetVec(i, rnd.NormFloat64())
+		}
+		var want VecDense
+		err := want.SolveVec(a, b)
+		if err != nil {
+			t.Fatalf("n=%d: unexpected error from direct solve: %v", n, err)
+		}
+
+		for _, m := range []Matrix{a, asBasicMatrix(a)} {
+			var x VecDense
+			resid := Jacobi(&x, m, b, 200)
+			if resid > 1e-10 {
+				t.Errorf("n=%d %T: Jacobi residual too large: %v", n, m, resid)
+			}
+			if !EqualApprox(&x, &want, 1e-10) {
+				t.Errorf("n=%d %T: unexpected Jacobi solution:\ngot: %v\nwant:%v", n, m, x.RawVector().Data, want.RawVector().Data)
+			}
+
+			for _, omega := range []float64{1, 1.1} {
+				var x VecDense
+				resid := SOR(&x, m, b, omega, 100)
+				if resid > 1e-10 {
+					t.Errorf("n=%d %T omega=%v: SOR residual too large: %v", n, m, omega, resid)
+				}
+				if !EqualApprox(&x, &want, 1e-10) {
+					t.Errorf("n=%d %T omega=%v: unexpected SOR solution:\ngot: %v\nwant:%v", n, m, omega, x.RawVector().Data, want.RawVector().Data)
+				}
+			}
+		}
+
+		// Warm start from the solution with no iterations.
+		x := VecDenseCopyOf(&want)
+		if resid := SOR(x, a, b, 1, 0); resid > 1e-12 {
+			t.Errorf("n=%d: unexpected residual from warm start: %v", n, resid)
+		}
+		if !Equal(x, &want) {
+			t.Errorf("n=%d: solution changed with no iterations", n)
+		}
+	}
+
+	a := NewDense(2, 2, []float64{1, 2, 3, 0})
+	b := NewVecDense(2, []float64{1, 1})
+	for _, test := range []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{name: "Jacobi zero diagonal", fn: func() { Jacobi(&VecDense{}, a, b, 1) }, want: "mat: zero diagonal element"},
+		{name: "SOR zero diagonal", fn: func() { SOR(&VecDense{}, a, b, 1, 1) }, want: "mat: zero diagonal element"},
+		{name: "SOR omega", fn: func() { SOR(&VecDense{}, NewDense(2, 2, []float64{1, 0, 0, 1}), b, 2, 1) }, want: "mat: SOR relaxation parameter out of range"},
+		{name: "non-square", fn: func() { Jacobi(&VecDense{}, NewDense(2, 3, nil), b, 1) }, want: ErrShape.Error()},
+		{name: "mismatched x", fn: func() { SOR(NewVecDense(3, nil), a, b, 1, 1) }, want: ErrShape.Error()},
+	} {
+		panicked, message := panics(test.fn)
+		if !panicked || message != test.want {
+			t.Errorf("%s: unexpected panic: got %q want %q", test.name, message, test.want)
+		}
+	}
+}
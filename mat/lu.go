@@ -17,6 +17,7 @@ import (
 const (
 	badSliceLength = "mat: improper slice length"
 	badLU          = "mat: invalid LU factorization"
+	badPermutation = "mat: invalid permutation"
 )
 
 // LU is a type for creating and using the LU factorization of a matrix.
@@ -368,7 +368,7 @@ func Row(dst []float64, i int, a Matrix) []float64 {
 
 // Cond returns the condition number of the given matrix under the given norm.
 // The condition number must be based on the 1-norm, 2-norm or ∞-norm.
-// Cond will panic with matrix.ErrShape if the matrix has zero size.
+// Cond will panic with matrix.ErrZeroLength if the matrix has zero size.
 //
 // BUG(btracey): The computation of the 1-norm and ∞-norm for non-square matrices
 // is inaccurate, although is typically the right order of magnitude. See
@@ -378,7 +378,7 @@ func Row(dst []float64, i int, a Matrix) []float64 {
 func Cond(a Matrix, norm float64) float64 {
 	m, n := a.Dims()
 	if m == 0 || n == 0 {
-		panic(ErrShape)
+		panic(ErrZeroLength)
 	}
 	var lnorm lapack.MatrixNorm
 	switch norm {
@@ -442,6 +442,143 @@ func Dot(a, b Vector) float64 {
 	return sum
 }
 
+// DotKahan returns the sum of the element-wise product of a and b, accumulated
+// using Neumaier's variant of Kahan compensated summation. The error of the
+// accumulation does not grow with the length of the vectors, in contrast to
+// Dot, at roughly twice the cost; each product is still rounded once.
+// DotKahan panics with ErrShape if the vector sizes are unequal.
+func DotKahan(a, b Vector) float64 {
+	n := a.Len()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	var sum, comp float64
+	if arv, ok := a.(RawVectorer); ok {
+		if brv, ok := b.(RawVectorer); ok {
+			amat, bmat := arv.RawVector(), brv.RawVector()
+			for i := 0; i < n; i++ {
+				x := amat.Data[i*amat.Inc] * bmat.Data[i*bmat.Inc]
+				t := sum + x
+				if math.Abs(sum) >= math.Abs(x) {
+					comp += (sum - t) + x
+				} else {
+					comp += (x - t) + sum
+				}
+				sum = t
+			}
+			return sum + comp
+		}
+	}
+	for i := 0; i < n; i++ {
+		x := a.AtVec(i) * b.AtVec(i)
+		t := sum + x
+		if math.Abs(sum) >= math.Abs(x) {
+			comp += (sum - t) + x
+		} else {
+			comp += (x - t) + sum
+		}
+		sum = t
+	}
+	return sum + comp
+}
+
+// EuclideanDistance returns the Euclidean distance between a and b,
+// sqrt(Σ (a[i]-b[i])²). The differences are accumulated directly, without
+// forming a temporary difference vector, and are scaled as they are summed
+// so that the result does not overflow for large finite differences.
+// EuclideanDistance panics with ErrShape if the vector sizes are unequal.
+func EuclideanDistance(a, b Vector) float64 {
+	n := a.Len()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	aAt, bAt := a.AtVec, b.AtVec
+	if rv, ok := a.(RawVectorer); ok {
+		amat := rv.RawVector()
+		aAt = func(i int) float64 { return amat.Data[i*amat.Inc] }
+	}
+	if rv, ok := b.(RawVectorer); ok {
+		bmat := rv.RawVector()
+		bAt = func(i int) float64 { return bmat.Data[i*bmat.Inc] }
+	}
+
+	var (
+		scale    float64
+		ssq      = 1.0
+		nan, inf bool
+	)
+	for i := 0; i < n; i++ {
+		d := math.Abs(aAt(i) - bAt(i))
+		switch {
+		case d == 0:
+		case math.IsNaN(d):
+			nan = true
+		case math.IsInf(d, 1):
+			inf = true
+		case scale < d:
+			ssq = 1 + ssq*(scale/d)*(scale/d)
+			scale = d
+		default:
+			ssq += (d / scale) * (d / scale)
+		}
+	}
+	switch {
+	case nan:
+		return math.NaN()
+	case inf:
+		return math.Inf(1)
+	}
+	return scale * math.Sqrt(ssq)
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b,
+// a·b/(‖a‖₂‖b‖₂). If either a or b has zero norm, CosineSimilarity
+// returns zero. CosineSimilarity panics with ErrShape if the vector sizes
+// are unequal.
+func CosineSimilarity(a, b Vector) float64 {
+	n := a.Len()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if n == 0 {
+		return 0
+	}
+	var dot float64
+	if arv, ok := a.(RawVectorer); ok {
+		if brv, ok := b.(RawVectorer); ok {
+			amat, bmat := arv.RawVector(), brv.RawVector()
+			dot = blas64.Dot(amat, bmat)
+			return cosine(dot, blas64.Nrm2(amat), blas64.Nrm2(bmat))
+		}
+	}
+	for i := 0; i < n; i++ {
+		dot += a.AtVec(i) * b.AtVec(i)
+	}
+	return cosine(dot, Norm(a, 2), Norm(b, 2))
+}
+
+func cosine(dot, aNorm, bNorm float64) float64 {
+	if aNorm == 0 || bNorm == 0 {
+		return 0
+	}
+	return dot / aNorm / bNorm
+}
+
+// Angle returns the angle in radians between a and b, in the range [0, π].
+// The cosine of the angle is clamped to [-1, 1] before the arccosine is
+// taken so that rounding error does not produce NaN for nearly parallel
+// vectors. If either a or b has zero norm, the angle is undefined and Angle
+// returns NaN. Angle panics with ErrShape if the vector sizes are unequal.
+func Angle(a, b Vector) float64 {
+	if a.Len() != b.Len() {
+		panic(ErrShape)
+	}
+	if Norm(a, 2) == 0 || Norm(b, 2) == 0 {
+		return math.NaN()
+	}
+	return math.Acos(math.Max(-1, math.Min(1, CosineSimilarity(a, b))))
+}
+
 // Equal returns whether the matrices a and b have the same size
 // and are element-wise equal.
 func Equal(a, b Matrix) bool {
@@ -585,22 +722,124 @@ func EqualApprox(a, b Matrix, epsilon float64) bool {
 	return true
 }
 
+// IsSymmetric returns whether a is square and, for all i and j, a[i,j] and
+// a[j,i] are equal or |a[i,j]-a[j,i]| <= tol. Equal infinite elements are
+// symmetric. Matrices implementing Symmetric are always reported as symmetric,
+// whatever their elements. Otherwise an off-diagonal NaN element is never
+// considered symmetric, and diagonal elements are not compared.
+func IsSymmetric(a Matrix, tol float64) bool {
+	return isSymmetric(a, func(x, y float64) bool {
+		return x == y || math.Abs(x-y) <= tol
+	})
+}
+
+// IsSymmetricExact returns whether a is square and a[i,j] == a[j,i] for all
+// i and j. Matrices implementing Symmetric are always reported as symmetric,
+// whatever their elements. Otherwise an off-diagonal NaN element is never
+// considered symmetric, and diagonal elements are not compared.
+func IsSymmetricExact(a Matrix) bool {
+	return isSymmetric(a, func(x, y float64) bool { return x == y })
+}
+
+// isSymmetric returns whether a is square and eq(a[i,j], a[j,i]) is true
+// for all i < j.
+func isSymmetric(a Matrix, eq func(x, y float64) bool) bool {
+	r, c := a.Dims()
+	if r != c {
+		return false
+	}
+	aU, _ := untranspose(a)
+	if _, ok := aU.(Symmetric); ok {
+		return true
+	}
+	if rm, ok := aU.(RawMatrixer); ok {
+		raw := rm.RawMatrix()
+		for i := 0; i < r; i++ {
+			for j := i + 1; j < r; j++ {
+				if !eq(raw.Data[i*raw.Stride+j], raw.Data[j*raw.Stride+i]) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	for i := 0; i < r; i++ {
+		for j := i + 1; j < r; j++ {
+			if !eq(aU.At(i, j), aU.At(j, i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Bandwidth returns the lower and upper bandwidths of a, the largest
+// distances below and above the diagonal of elements that are not
+// negligible. An element is negligible if its magnitude is no greater than
+// tol; NaN elements are never negligible. A diagonal matrix has bandwidths
+// of zero, and a may be stored in a BandDense with the returned kl and ku
+// without losing any non-negligible elements.
+func Bandwidth(a Matrix, tol float64) (kl, ku int) {
+	aU, trans := untranspose(a)
+	r, c := aU.Dims()
+	at := aU.At
+	if rm, ok := aU.(RawMatrixer); ok {
+		raw := rm.RawMatrix()
+		at = func(i, j int) float64 { return raw.Data[i*raw.Stride+j] }
+	}
+	for i := 0; i < r; i++ {
+		// Only elements outside the current band need to be examined.
+		for j := 0; j < min(i-kl, c); j++ {
+			if !(math.Abs(at(i, j)) <= tol) {
+				kl = i - j
+				break
+			}
+		}
+		for j := c - 1; j > i+ku; j-- {
+			if !(math.Abs(at(i, j)) <= tol) {
+				ku = j - i
+				break
+			}
+		}
+	}
+	if trans {
+		kl, ku = ku, kl
+	}
+	return kl, ku
+}
+
 // LogDet returns the log of the determinant and the sign of the determinant
 // for the matrix that has been factorized. Numerical stability in product and
 // division expressions is generally improved by working in log space.
+//
+// The determinant of a Triangular matrix is computed directly from its diagonal
+// without a factorization.
 func LogDet(a Matrix) (det float64, sign float64) {
-	// TODO(btracey): Add specialized routines for TriDense, etc.
+	// TODO(btracey): Add specialized routines for other matrix kinds.
+	aU, _ := untranspose(a)
+	if t, ok := aU.(Triangular); ok {
+		n, _ := t.Triangle()
+		sign = 1
+		for i := 0; i < n; i++ {
+			v := t.At(i, i)
+			if v < 0 {
+				sign *= -1
+			}
+			det += math.Log(math.Abs(v))
+		}
+		return det, sign
+	}
 	var lu LU
 	lu.Factorize(a)
 	return lu.LogDet()
 }
 
 // Max returns the largest element value of the matrix A.
-// Max will panic with matrix.ErrShape if the matrix has zero size.
+// Max will panic with matrix.ErrZeroLength if the matrix has zero size.
 func Max(a Matrix) float64 {
 	r, c := a.Dims()
 	if r == 0 || c == 0 {
-		panic(ErrShape)
+		panic(ErrZeroLength)
 	}
 	// Max(A) = Max(Aᵀ)
 	aU, _ := untranspose(a)
@@ -671,11 +910,11 @@ func Max(a Matrix) float64 {
 }
 
 // Min returns the smallest element value of the matrix A.
-// Min will panic with matrix.ErrShape if the matrix has zero size.
+// Min will panic with matrix.ErrZeroLength if the matrix has zero size.
 func Min(a Matrix) float64 {
 	r, c := a.Dims()
 	if r == 0 || c == 0 {
-		panic(ErrShape)
+		panic(ErrZeroLength)
 	}
 	// Min(A) = Min(Aᵀ)
 	aU, _ := untranspose(a)
@@ -753,11 +992,15 @@ func Min(a Matrix) float64 {
 //    2 - Frobenius norm, the square root of the sum of the squares of the elements.
 //  Inf - The maximum absolute row sum.
 // Norm will panic with ErrNormOrder if an illegal norm order is specified and
-// with matrix.ErrShape if the matrix has zero size.
+// with matrix.ErrZeroLength if the matrix has zero size.
+//
+// None of the norms require a factorization of a. The spectral norm, the
+// largest singular value of a, is not provided by Norm; it can be obtained
+// from the first value returned by SVD.Values.
 func Norm(a Matrix, norm float64) float64 {
 	r, c := a.Dims()
 	if r == 0 || c == 0 {
-		panic(ErrShape)
+		panic(ErrZeroLength)
 	}
 	aU, aTrans := untranspose(a)
 	var work []float64
@@ -7,6 +7,7 @@ package mat
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -332,6 +333,37 @@ func TestDet(t *testing.T) {
 			t.Errorf("Det mismatch case %d. Got %v, want %v", c, det, test.ans)
 		}
 	}
+	for c, test := range []struct {
+		a   Matrix
+		ans float64
+	}{
+		{
+			a: NewTriDense(3, Upper, []float64{
+				2, 5, 7,
+				0, -3, 1,
+				0, 0, 4,
+			}),
+			ans: -24,
+		},
+		{
+			a: NewTriDense(3, Lower, []float64{
+				2, 0, 0,
+				5, -3, 0,
+				7, 1, -4,
+			}).T(),
+			ans: 24,
+		},
+		{
+			a:   NewDiagDense(3, []float64{1, 0, 3}),
+			ans: 0,
+		},
+	} {
+		det := Det(test.a)
+		if !floats.EqualWithinAbsOrRel(det, test.ans, 1e-14, 1e-14) {
+			t.Errorf("Det mismatch triangular case %d. Got %v, want %v", c, det, test.ans)
+		}
+	}
+
 	// Perform the normal list test to ensure it works for all types.
 	f := func(a Matrix) interface{} {
 		return Det(a)
@@ -447,6 +479,334 @@ func TestEqual(t *testing.T) {
 	testTwoInputFunc(t, "Equal", f, denseComparison, sameAnswerBool, legalTypesAll, isAnySize2)
 }
 
+func TestIsSymmetric(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a     Matrix
+		tol   float64
+		want  bool
+		exact bool
+	}{
+		{a: NewDense(1, 1, []float64{3}), want: true, exact: true},
+		{a: NewDense(2, 3, nil), want: false, exact: false},
+		{a: NewDense(2, 2, []float64{1, 2, 2, 1}), want: true, exact: true},
+		{a: NewDense(2, 2, []float64{1, 2, 2 + 1e-12, 1}), tol: 1e-10, want: true, exact: false},
+		{a: NewDense(2, 2, []float64{1, 2, 2.1, 1}), tol: 1e-10, want: false, exact: false},
+		{a: NewDense(2, 2, []float64{1, 2, 2.1, 1}).T(), tol: 0.2, want: true, exact: false},
+		{a: NewDense(2, 2, []float64{math.NaN(), 0, 0, 1}), tol: 1, want: true, exact: true},
+		{a: NewDense(2, 2, []float64{1, math.NaN(), 0, 1}), tol: 1, want: false, exact: false},
+		{a: NewDense(2, 2, []float64{1, math.NaN(), math.NaN(), 1}), tol: 1, want: false, exact: false},
+		{a: NewSymDense(2, []float64{1, math.NaN(), math.NaN(), 1}), tol: 1, want: true, exact: true},
+		{a: NewDense(2, 2, []float64{1, math.Inf(1), math.Inf(1), 1}), want: true, exact: true},
+		{a: NewDense(2, 2, []float64{1, math.Inf(-1), math.Inf(-1), 1}), tol: 1, want: true, exact: true},
+		{a: NewDense(2, 2, []float64{1, math.Inf(1), math.Inf(-1), 1}), tol: 1, want: false, exact: false},
+		{a: asBasicMatrix(NewDense(2, 2, []float64{1, math.Inf(1), math.Inf(1), 1})), want: true, exact: true},
+		{a: NewSymDense(2, []float64{1, 2, 2, 1}), want: true, exact: true},
+		{a: asBasicMatrix(NewDense(3, 3, []float64{1, 2, 3, 2, 4, 5, 3, 5, 6})), want: true, exact: true},
+		{a: asBasicMatrix(NewDense(3, 3, []float64{1, 2, 3, 2, 4, 5, 3, 5.5, 6})), tol: 0.1, want: false, exact: false},
+	} {
+		if got := IsSymmetric(test.a, test.tol); got != test.want {
+			t.Errorf("test %d: unexpected IsSymmetric result: got: %t want: %t", i, got, test.want)
+		}
+		if got := IsSymmetricExact(test.a); got != test.exact {
+			t.Errorf("test %d: unexpected IsSymmetricExact result: got: %t want: %t", i, got, test.exact)
+		}
+	}
+}
+
+func TestEuclideanDistanceCosineSimilarity(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a, b   Vector
+		dist   float64
+		cosine float64
+	}{
+		{
+			a:      NewVecDense(2, []float64{0, 0}),
+			b:      NewVecDense(2, []float64{3, 4}),
+			dist:   5,
+			cosine: 0,
+		},
+		{
+			a:      NewVecDense(2, []float64{1, 0}),
+			b:      NewVecDense(2, []float64{0, 2}),
+			dist:   math.Sqrt(5),
+			cosine: 0,
+		},
+		{
+			a:      NewVecDense(3, []float64{1, 2, 3}),
+			b:      NewVecDense(3, []float64{2, 4, 6}),
+			dist:   math.Sqrt(14),
+			cosine: 1,
+		},
+		{
+			a:      NewVecDense(2, []float64{1, 1}),
+			b:      NewDense(2, 2, []float64{-1, 0, -1, 0}).ColView(0),
+			dist:   math.Sqrt(8),
+			cosine: -1,
+		},
+		{
+			a:      &basicVector{m: []float64{1, 0}},
+			b:      NewVecDense(2, []float64{1, 1}),
+			dist:   1,
+			cosine: 1 / math.Sqrt2,
+		},
+		{
+			a:      NewVecDense(2, []float64{3e200, 0}),
+			b:      NewVecDense(2, []float64{0, 4e200}),
+			dist:   5e200,
+			cosine: 0,
+		},
+		{
+			a:      NewVecDense(2, []float64{3e-200, 0}),
+			b:      NewVecDense(2, []float64{0, 4e-200}),
+			dist:   5e-200,
+			cosine: 0,
+		},
+	} {
+		if got := EuclideanDistance(test.a, test.b); math.Abs(got-test.dist) > 1e-15*test.dist {
+			t.Errorf("test %d: unexpected distance: got: %v want: %v", i, got, test.dist)
+		}
+		if got := CosineSimilarity(test.a, test.b); math.Abs(got-test.cosine) > 1e-15 {
+			t.Errorf("test %d: unexpected cosine similarity: got: %v want: %v", i, got, test.cosine)
+		}
+	}
+
+	a := NewVecDense(2, nil)
+	b := NewVecDense(3, nil)
+	if panicked, _ := panics(func() { EuclideanDistance(a, b) }); !panicked {
+		t.Error("expected panic for EuclideanDistance length mismatch")
+	}
+	if panicked, _ := panics(func() { CosineSimilarity(a, b) }); !panicked {
+		t.Error("expected panic for CosineSimilarity length mismatch")
+	}
+}
+
+func TestAngle(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a, b Vector
+		want float64
+	}{
+		{
+			a:    NewVecDense(2, []float64{1, 0}),
+			b:    NewVecDense(2, []float64{0, 2}),
+			want: math.Pi / 2,
+		},
+		{
+			a:    NewVecDense(2, []float64{1, 1}),
+			b:    &basicVector{m: []float64{1, 0}},
+			want: math.Pi / 4,
+		},
+		{
+			a:    NewVecDense(2, []float64{1, 1}),
+			b:    NewDense(2, 2, []float64{-1, 0, -1, 0}).ColView(0),
+			want: math.Pi,
+		},
+		{
+			// Rounding can push the cosine of parallel vectors above 1.
+			a:    NewVecDense(3, []float64{0.1, 0.2, 0.3}),
+			b:    NewVecDense(3, []float64{0.3, 0.6, 0.9}),
+			want: 0,
+		},
+	} {
+		if got := Angle(test.a, test.b); math.IsNaN(got) || math.Abs(got-test.want) > 1e-7 {
+			t.Errorf("test %d: unexpected angle: got: %v want: %v", i, got, test.want)
+		}
+	}
+
+	if got := Angle(NewVecDense(2, nil), NewVecDense(2, []float64{1, 2})); !math.IsNaN(got) {
+		t.Errorf("unexpected angle for zero vector: got: %v want: NaN", got)
+	}
+	if panicked, _ := panics(func() { Angle(NewVecDense(2, nil), NewVecDense(3, nil)) }); !panicked {
+		t.Error("expected panic for Angle length mismatch")
+	}
+}
+
+func TestBandwidth(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a      Matrix
+		tol    float64
+		kl, ku int
+	}{
+		{
+			a:  NewDense(3, 3, []float64{1, 0, 0, 0, 2, 0, 0, 0, 3}),
+			kl: 0, ku: 0,
+		},
+		{
+			a: NewDense(4, 4, []float64{
+				1, 2, 0, 0,
+				3, 1, 2, 0,
+				0, 3, 1, 2,
+				0, 0, 3, 1,
+			}),
+			kl: 1, ku: 1,
+		},
+		{
+			a: NewDense(4, 5, []float64{
+				1, 0, 0, 4, 0,
+				0, 1, 0, 0, 0,
+				5, 0, 1, 0, 0,
+				0, 0, 0, 1, 0,
+			}),
+			kl: 2, ku: 3,
+		},
+		{
+			a: NewDense(4, 5, []float64{
+				1, 0, 0, 4, 0,
+				0, 1, 0, 0, 0,
+				5, 0, 1, 0, 0,
+				0, 0, 0, 1, 0,
+			}).T(),
+			kl: 3, ku: 2,
+		},
+		{
+			a: asBasicMatrix(NewDense(3, 3, []float64{
+				1, 1e-12, 0,
+				0, 1, 0,
+				-1e-12, 0, 1,
+			})),
+			tol: 1e-10,
+			kl:  0, ku: 0,
+		},
+		{
+			a: NewDense(3, 3, []float64{
+				1, 1e-12, 0,
+				0, 1, 0,
+				-1e-12, 0, 1,
+			}),
+			kl: 2, ku: 1,
+		},
+		{
+			a: NewDense(3, 2, []float64{
+				1, 0,
+				0, 1,
+				math.NaN(), 0,
+			}),
+			kl: 2, ku: 0,
+		},
+		{
+			a: NewBandDense(5, 5, 1, 2, []float64{
+				-1, 1, 2, 3,
+				1, 2, 3, 4,
+				5, 6, 7, 8,
+				9, 10, 11, -1,
+				12, 13, -1, -1,
+			}),
+			kl: 1, ku: 2,
+		},
+	} {
+		kl, ku := Bandwidth(test.a, test.tol)
+		if kl != test.kl || ku != test.ku {
+			t.Errorf("unexpected bandwidth for test %d: got (%d, %d) want (%d, %d)", i, kl, ku, test.kl, test.ku)
+		}
+	}
+}
+
+func TestDotKahan(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a, b Vector
+		want float64
+	}{
+		{
+			a:    NewVecDense(3, []float64{1, 2, 3}),
+			b:    NewVecDense(3, []float64{4, -5, 6}),
+			want: 12,
+		},
+		{
+			// Naive summation loses the small terms entirely.
+			a:    NewVecDense(4, []float64{1e16, 1, 1, -1e16}),
+			b:    NewVecDense(4, []float64{1, 1, 1, 1}),
+			want: 2,
+		},
+		{
+			a: &VecDense{mat: blas64.Vector{N: 4, Inc: 2, Data: []float64{
+				1, 0, 1e100, 0, 1, 0, -1e100,
+			}}},
+			b:    &basicVector{m: []float64{1, 1, 1, 1}},
+			want: 2,
+		},
+	} {
+		if got := DotKahan(test.a, test.b); got != test.want {
+			t.Errorf("unexpected result for test %d: got %v want %v", i, got, test.want)
+		}
+	}
+
+	// DotKahan must be at least as accurate as Dot on long ill-conditioned sums.
+	rnd := rand.New(rand.NewSource(1))
+	a, b := illConditionedDotVecs(100000, rnd)
+	want := exactDot(a, b)
+	errDot := math.Abs(Dot(a, b) - want)
+	errKahan := math.Abs(DotKahan(a, b) - want)
+	if errKahan > errDot {
+		t.Errorf("DotKahan less accurate than Dot: error %v > %v", errKahan, errDot)
+	}
+
+	panicked, message := panics(func() { DotKahan(NewVecDense(2, nil), NewVecDense(3, nil)) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched lengths, got %q", message)
+	}
+}
+
+// illConditionedDotVecs returns vectors of length n whose products span many
+// orders of magnitude and largely cancel, leaving a sum that is small
+// relative to the individual products.
+func illConditionedDotVecs(n int, rnd *rand.Rand) (a, b *VecDense) {
+	a = NewVecDense(n, nil)
+	b = NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		switch i % 3 {
+		case 0:
+			// Large products, cancelled exactly by the next case.
+			a.SetVec(i, rnd.NormFloat64()*math.Pow(10, float64(rnd.Intn(20))))
+			b.SetVec(i, 1)
+		case 1:
+			a.SetVec(i, -a.AtVec(i-1))
+			b.SetVec(i, 1)
+		case 2:
+			// Small products carrying the result.
+			a.SetVec(i, rnd.NormFloat64())
+			b.SetVec(i, rnd.NormFloat64())
+		}
+	}
+	p := rnd.Perm(n)
+	a.Permute(p, false)
+	b.Permute(p, false)
+	return a, b
+}
+
+// exactDot returns the dot product of a and b computed in extended
+// precision and rounded to float64.
+func exactDot(a, b Vector) float64 {
+	sum := new(big.Float).SetPrec(2048)
+	for i := 0; i < a.Len(); i++ {
+		p := new(big.Float).SetPrec(2048).SetFloat64(a.AtVec(i))
+		p.Mul(p, new(big.Float).SetFloat64(b.AtVec(i)))
+		sum.Add(sum, p)
+	}
+	f, _ := sum.Float64()
+	return f
+}
+
+func BenchmarkDot100000(b *testing.B)      { dotBench(b, Dot) }
+func BenchmarkDotKahan100000(b *testing.B) { dotBench(b, DotKahan) }
+
+// dotBench benchmarks dot and reports its relative error compared to the
+// extended precision result.
+func dotBench(b *testing.B, dot func(a, b Vector) float64) {
+	x, y := illConditionedDotVecs(100000, rand.New(rand.NewSource(1)))
+	want := exactDot(x, y)
+	var got float64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		got = dot(x, y)
+	}
+	b.StopTimer()
+	b.ReportMetric(math.Abs(got-want)/math.Abs(want), "relerr")
+}
+
 func TestMax(t *testing.T) {
 	t.Parallel()
 	// A direct test of Max with *Dense arguments is in TestNewDense.
@@ -543,9 +903,38 @@ func TestNormZero(t *testing.T) {
 			if !panicked {
 				t.Errorf("expected panic for Norm(&%T{}, %v)", a, norm)
 			}
-			if message != ErrShape.Error() {
+			if message != ErrZeroLength.Error() {
 				t.Errorf("unexpected panic string for Norm(&%T{}, %v): got:%s want:%s",
-					a, norm, message, ErrShape.Error())
+					a, norm, message, ErrZeroLength.Error())
+			}
+		}
+	}
+}
+
+func TestMaxMinZero(t *testing.T) {
+	t.Parallel()
+	for _, a := range []Matrix{
+		&Dense{},
+		&SymDense{},
+		&TriDense{},
+		&VecDense{},
+	} {
+		for _, test := range []struct {
+			name string
+			fn   func(Matrix) float64
+		}{
+			{name: "Max", fn: Max},
+			{name: "Min", fn: Min},
+			{name: "Cond", fn: func(a Matrix) float64 { return Cond(a, 1) }},
+		} {
+			err := Maybe(func() { test.fn(a) })
+			if err == nil {
+				t.Errorf("expected panic for %s(&%T{})", test.name, a)
+				continue
+			}
+			if err.(ErrorStack).Err != ErrZeroLength {
+				t.Errorf("unexpected error for %s(&%T{}): got:%v want:%v",
+					test.name, a, err, ErrZeroLength)
 			}
 		}
 	}
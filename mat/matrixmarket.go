@@ -0,0 +1,355 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const mmBanner = "%%MatrixMarket"
+
+var errMMBanner = errors.New("mat: missing MatrixMarket banner")
+
+// maxMMDense is the largest number of elements of a matrix that
+// ReadMatrixMarket will expand coordinate format input into.
+const maxMMDense = 1 << 27
+
+// ReadMatrixMarket reads a real matrix in MatrixMarket exchange format from r.
+// Both the array and coordinate formats are supported, with real, integer
+// or pattern fields and general, symmetric or skew-symmetric storage.
+// Comment lines are ignored.
+//
+// Matrices with symmetric storage are returned as a *SymDense. All other
+// matrices are returned as a *Dense, with the missing triangle of
+// skew-symmetric matrices filled by negating the stored values. Coordinate
+// format input is expanded into dense storage, with elements not present
+// in the input set to zero and duplicate entries summed. An error is
+// returned if the dense matrix would have more than 2^27 elements.
+//
+// ReadMatrixMarket is intended for dense data. Sparse coordinate format
+// input should be read with ReadMatrixMarketCOO, which returns the sparse
+// COO representation without expanding it.
+//
+// See https://math.nist.gov/MatrixMarket/formats.html for a description
+// of the format.
+func ReadMatrixMarket(r io.Reader) (Matrix, error) {
+	s, err := newMMScanner(r)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := s.rows, s.cols
+	if s.format == "coordinate" && rows > maxMMDense/cols {
+		return nil, fmt.Errorf("mat: MatrixMarket matrix too large for dense storage: %d×%d", rows, cols)
+	}
+
+	var add func(i, j int, v float64)
+	var m Matrix
+	switch s.symmetry {
+	case "symmetric":
+		sym := NewSymDense(rows, nil)
+		add = func(i, j int, v float64) {
+			sym.SetSym(i, j, sym.At(i, j)+v)
+		}
+		m = sym
+	case "skew-symmetric":
+		d := NewDense(rows, cols, nil)
+		add = func(i, j int, v float64) {
+			d.Set(i, j, d.At(i, j)+v)
+			d.Set(j, i, d.At(j, i)-v)
+		}
+		m = d
+	default:
+		d := NewDense(rows, cols, nil)
+		add = func(i, j int, v float64) {
+			d.Set(i, j, d.At(i, j)+v)
+		}
+		m = d
+	}
+
+	if s.format == "coordinate" {
+		err = s.coordinates(add)
+	} else {
+		err = s.array(add)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReadMatrixMarketCOO reads a real sparse matrix in MatrixMarket coordinate
+// format from r into a COO without expanding it into dense storage. Real,
+// integer and pattern fields and general, symmetric and skew-symmetric
+// storage are supported. The entries of symmetric and skew-symmetric
+// matrices are mirrored into the missing triangle, negated in the
+// skew-symmetric case. Duplicate entries are summed when the COO is
+// converted. Comment lines are ignored.
+//
+// ReadMatrixMarketCOO returns an error if the input is in array format.
+func ReadMatrixMarketCOO(r io.Reader) (*COO, error) {
+	s, err := newMMScanner(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.format != "coordinate" {
+		return nil, fmt.Errorf("mat: MatrixMarket %s format is not sparse", s.format)
+	}
+	m := NewCOO(s.rows, s.cols)
+	err = s.coordinates(func(i, j int, v float64) {
+		m.Add(i, j, v)
+		if i == j {
+			return
+		}
+		switch s.symmetry {
+		case "symmetric":
+			m.Add(j, i, v)
+		case "skew-symmetric":
+			m.Add(j, i, -v)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// mmScanner reads the entries of a MatrixMarket file after its banner
+// and size line have been parsed.
+type mmScanner struct {
+	sc   *bufio.Scanner
+	line int
+
+	format, field, symmetry string
+
+	rows, cols, nnz int
+}
+
+// newMMScanner parses the banner and size line read from r.
+func newMMScanner(r io.Reader) (*mmScanner, error) {
+	s := &mmScanner{sc: bufio.NewScanner(r)}
+	if !s.sc.Scan() {
+		if err := s.sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errMMBanner
+	}
+	s.line++
+	banner := strings.Fields(strings.ToLower(s.sc.Text()))
+	if len(banner) != 5 || banner[0] != strings.ToLower(mmBanner) || banner[1] != "matrix" {
+		return nil, errMMBanner
+	}
+	s.format, s.field, s.symmetry = banner[2], banner[3], banner[4]
+	switch s.format {
+	case "array", "coordinate":
+	default:
+		return nil, fmt.Errorf("mat: unsupported MatrixMarket format %q", s.format)
+	}
+	switch s.field {
+	case "real", "double", "integer":
+	case "pattern":
+		if s.format == "array" {
+			return nil, errors.New("mat: MatrixMarket pattern field requires coordinate format")
+		}
+	default:
+		return nil, fmt.Errorf("mat: unsupported MatrixMarket field %q", s.field)
+	}
+	switch s.symmetry {
+	case "general", "symmetric", "skew-symmetric":
+	default:
+		return nil, fmt.Errorf("mat: unsupported MatrixMarket symmetry %q", s.symmetry)
+	}
+
+	f, err := s.next()
+	if err != nil {
+		return nil, err
+	}
+	wantSize := 2
+	if s.format == "coordinate" {
+		wantSize = 3
+	}
+	if len(f) != wantSize {
+		return nil, fmt.Errorf("mat: invalid MatrixMarket size line at line %d", s.line)
+	}
+	size, err := s.ints(f)
+	if err != nil {
+		return nil, err
+	}
+	s.rows, s.cols = size[0], size[1]
+	if s.rows < 0 || s.cols < 0 {
+		return nil, ErrNegativeDimension
+	}
+	if s.rows == 0 || s.cols == 0 {
+		return nil, ErrZeroLength
+	}
+	if s.symmetry != "general" && s.rows != s.cols {
+		return nil, fmt.Errorf("mat: non-square %s MatrixMarket matrix", s.symmetry)
+	}
+	if s.format == "coordinate" {
+		s.nnz = size[2]
+		if s.nnz < 0 {
+			return nil, fmt.Errorf("mat: invalid MatrixMarket entry count at line %d", s.line)
+		}
+	}
+	return s, nil
+}
+
+// next returns the fields of the next non-comment line.
+func (s *mmScanner) next() ([]string, error) {
+	for s.sc.Scan() {
+		s.line++
+		text := strings.TrimSpace(s.sc.Text())
+		if text == "" || text[0] == '%' {
+			continue
+		}
+		return strings.Fields(text), nil
+	}
+	if err := s.sc.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (s *mmScanner) ints(f []string) ([]int, error) {
+	v := make([]int, len(f))
+	for i, str := range f {
+		var err error
+		v[i], err = strconv.Atoi(str)
+		if err != nil {
+			return nil, fmt.Errorf("mat: invalid MatrixMarket integer %q at line %d", str, s.line)
+		}
+	}
+	return v, nil
+}
+
+func (s *mmScanner) value(str string) (float64, error) {
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("mat: invalid MatrixMarket value %q at line %d", str, s.line)
+	}
+	return v, nil
+}
+
+// array reads the entries of an array format matrix, calling set for each.
+func (s *mmScanner) array(set func(i, j int, v float64)) error {
+	// Array values are stored in column-major order, and
+	// only the lower triangle is stored for symmetric
+	// matrices and the strict lower triangle for
+	// skew-symmetric matrices.
+	for j := 0; j < s.cols; j++ {
+		start := 0
+		switch s.symmetry {
+		case "symmetric":
+			start = j
+		case "skew-symmetric":
+			start = j + 1
+		}
+		for i := start; i < s.rows; i++ {
+			f, err := s.next()
+			if err != nil {
+				return err
+			}
+			if len(f) != 1 {
+				return fmt.Errorf("mat: invalid MatrixMarket array entry at line %d", s.line)
+			}
+			v, err := s.value(f[0])
+			if err != nil {
+				return err
+			}
+			set(i, j, v)
+		}
+	}
+	return nil
+}
+
+// coordinates reads the entries of a coordinate format matrix, calling add
+// for each with zero-based indices.
+func (s *mmScanner) coordinates(add func(i, j int, v float64)) error {
+	wantFields := 3
+	if s.field == "pattern" {
+		wantFields = 2
+	}
+	for k := 0; k < s.nnz; k++ {
+		f, err := s.next()
+		if err != nil {
+			return err
+		}
+		if len(f) != wantFields {
+			return fmt.Errorf("mat: invalid MatrixMarket coordinate entry at line %d", s.line)
+		}
+		idx, err := s.ints(f[:2])
+		if err != nil {
+			return err
+		}
+		i, j := idx[0]-1, idx[1]-1
+		if i < 0 || s.rows <= i || j < 0 || s.cols <= j {
+			return fmt.Errorf("mat: MatrixMarket index out of range at line %d", s.line)
+		}
+		v := 1.0
+		if s.field != "pattern" {
+			v, err = s.value(f[2])
+			if err != nil {
+				return err
+			}
+		}
+		add(i, j, v)
+	}
+	return nil
+}
+
+// WriteMatrixMarket writes the matrix m to w in MatrixMarket array format.
+// If m is Symmetric, only its lower triangle is written and the matrix is
+// marked as symmetric, otherwise it is written as a general matrix.
+func WriteMatrixMarket(w io.Writer, m Matrix) error {
+	r, c := m.Dims()
+	if r == 0 || c == 0 {
+		return ErrZeroLength
+	}
+	_, sym := m.(Symmetric)
+	symmetry := "general"
+	if sym {
+		symmetry = "symmetric"
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix array real %s\n%d %d\n", mmBanner, symmetry, r, c)
+	for j := 0; j < c; j++ {
+		start := 0
+		if sym {
+			start = j
+		}
+		for i := start; i < r; i++ {
+			bw.WriteString(strconv.FormatFloat(m.At(i, j), 'g', -1, 64))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteMatrixMarketCOO writes the sparse matrix m to w in MatrixMarket
+// coordinate format as a general real matrix, with duplicate entries of m
+// summed. The entries are written in row-major order, and the receiver is
+// updated to hold the combined entries, as for COO.ToCSR.
+func WriteMatrixMarketCOO(w io.Writer, m *COO) error {
+	r, c := m.Dims()
+	if r == 0 || c == 0 {
+		return ErrZeroLength
+	}
+	m.compress()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix coordinate real general\n%d %d %d\n", mmBanner, r, c, len(m.data))
+	for k, v := range m.data {
+		bw.WriteString(strconv.Itoa(m.rows[k] + 1))
+		bw.WriteByte(' ')
+		bw.WriteString(strconv.Itoa(m.cols[k] + 1))
+		bw.WriteByte(' ')
+		bw.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,305 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		in   string
+		want Matrix
+		err  bool
+	}{
+		{
+			in: `%%MatrixMarket matrix array real general
+% A comment.
+2 3
+1
+4
+2
+5
+3
+6
+`,
+			want: NewDense(2, 3, []float64{
+				1, 2, 3,
+				4, 5, 6,
+			}),
+		},
+		{
+			in: `%%MatrixMarket matrix array real symmetric
+3 3
+1
+2
+3
+4
+5
+6
+`,
+			want: NewSymDense(3, []float64{
+				1, 2, 3,
+				2, 4, 5,
+				3, 5, 6,
+			}),
+		},
+		{
+			in: `%%MatrixMarket matrix coordinate real general
+%
+3 2 3
+
+1 1 1.5
+3 2 -2
+2 1 4e2
+`,
+			want: NewDense(3, 2, []float64{
+				1.5, 0,
+				400, 0,
+				0, -2,
+			}),
+		},
+		{
+			in: `%%MatrixMarket matrix coordinate integer symmetric
+3 3 3
+1 1 1
+3 1 2
+3 2 3
+`,
+			want: NewSymDense(3, []float64{
+				1, 0, 2,
+				0, 0, 3,
+				2, 3, 0,
+			}),
+		},
+		{
+			in: `%%MatrixMarket matrix coordinate pattern skew-symmetric
+2 2 1
+2 1
+`,
+			want: NewDense(2, 2, []float64{
+				0, -1,
+				1, 0,
+			}),
+		},
+		{
+			in: `%%MatrixMarket matrix coordinate real general
+2 2 4
+1 1 1
+2 1 2
+1 1 3
+2 1 -0.5
+`,
+			want: NewDense(2, 2, []float64{
+				4, 0,
+				1.5, 0,
+			}),
+		},
+		{
+			in: `%%MatrixMarket matrix coordinate real symmetric
+2 2 3
+2 1 1
+2 1 2
+2 2 5
+`,
+			want: NewSymDense(2, []float64{
+				0, 3,
+				3, 5,
+			}),
+		},
+		{
+			in:  "%%MatrixMarket matrix coordinate real general\n1000000 1000000 1\n1 1 1\n",
+			err: true,
+		},
+		{
+			in:  "1 1\n1\n",
+			err: true,
+		},
+		{
+			in:  "%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0\n",
+			err: true,
+		},
+		{
+			in:  "%%MatrixMarket matrix array real symmetric\n2 3\n",
+			err: true,
+		},
+		{
+			in:  "%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+			err: true,
+		},
+		{
+			in:  "%%MatrixMarket matrix array real general\n2 2\n1\n2\n3\n",
+			err: true,
+		},
+	} {
+		got, err := ReadMatrixMarket(strings.NewReader(test.in))
+		if test.err {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if _, ok := test.want.(*SymDense); ok {
+			if _, ok := got.(*SymDense); !ok {
+				t.Errorf("test %d: unexpected type: got %T want *SymDense", i, got)
+			}
+		}
+		if !Equal(got, test.want) {
+			t.Errorf("test %d: unexpected result:\ngot:\n%v\nwant:\n%v", i, Formatted(got), Formatted(test.want))
+		}
+	}
+}
+
+func TestReadMatrixMarketCOO(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		in   string
+		want *Dense
+		nnz  int
+		err  bool
+	}{
+		{
+			in: `%%MatrixMarket matrix coordinate real general
+% Duplicate entries are summed.
+3 2 4
+1 1 1.5
+3 2 -2
+1 1 0.5
+2 1 4e2
+`,
+			want: NewDense(3, 2, []float64{
+				2, 0,
+				400, 0,
+				0, -2,
+			}),
+			nnz: 4,
+		},
+		{
+			in: `%%MatrixMarket matrix coordinate integer symmetric
+3 3 3
+1 1 1
+3 1 2
+3 2 3
+`,
+			want: NewDense(3, 3, []float64{
+				1, 0, 2,
+				0, 0, 3,
+				2, 3, 0,
+			}),
+			nnz: 5,
+		},
+		{
+			in: `%%MatrixMarket matrix coordinate pattern skew-symmetric
+2 2 1
+2 1
+`,
+			want: NewDense(2, 2, []float64{
+				0, -1,
+				1, 0,
+			}),
+			nnz: 2,
+		},
+		{
+			// Large dimensions must not cause a dense allocation.
+			in:  "%%MatrixMarket matrix coordinate real general\n1000000000 1000000000 2\n1 1 1\n1000000000 1000000000 2\n",
+			nnz: 2,
+		},
+		{
+			in:  "%%MatrixMarket matrix array real general\n1 1\n1\n",
+			err: true,
+		},
+		{
+			in:  "%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+			err: true,
+		},
+	} {
+		got, err := ReadMatrixMarketCOO(strings.NewReader(test.in))
+		if test.err {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if n := got.NNZ(); n != test.nnz {
+			t.Errorf("test %d: unexpected number of entries: got %d want %d", i, n, test.nnz)
+		}
+		if test.want == nil {
+			continue
+		}
+		if d := got.ToDense(); !Equal(d, test.want) {
+			t.Errorf("test %d: unexpected result:\ngot:\n%v\nwant:\n%v", i, Formatted(d), Formatted(test.want))
+		}
+	}
+}
+
+func TestMatrixMarketRoundTrip(t *testing.T) {
+	t.Parallel()
+	for i, m := range []Matrix{
+		NewDense(2, 3, []float64{1, 2.5, -3, 0.1, 0, 1e-300}),
+		NewSymDense(3, []float64{1, 2, 3, 2, 4, 5, 3, 5, 6}),
+	} {
+		var buf bytes.Buffer
+		err := WriteMatrixMarket(&buf, m)
+		if err != nil {
+			t.Errorf("test %d: unexpected error writing: %v", i, err)
+			continue
+		}
+		got, err := ReadMatrixMarket(&buf)
+		if err != nil {
+			t.Errorf("test %d: unexpected error reading: %v", i, err)
+			continue
+		}
+		if !Equal(got, m) {
+			t.Errorf("test %d: round trip mismatch:\ngot:\n%v\nwant:\n%v", i, Formatted(got), Formatted(m))
+		}
+	}
+}
+
+func TestMatrixMarketCOORoundTrip(t *testing.T) {
+	t.Parallel()
+	m := NewCOO(3, 4)
+	m.Add(2, 3, -1.5)
+	m.Add(0, 1, 2)
+	m.Add(2, 3, 0.5)
+	m.Add(1, 0, 1e-300)
+
+	var buf bytes.Buffer
+	err := WriteMatrixMarketCOO(&buf, m)
+	if err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+	want := `%%MatrixMarket matrix coordinate real general
+3 4 3
+1 2 2
+2 1 1e-300
+3 4 -1
+`
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	got, err := ReadMatrixMarketCOO(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if !Equal(got.ToDense(), m.ToDense()) {
+		t.Errorf("round trip mismatch:\ngot:\n%v\nwant:\n%v", Formatted(got.ToDense()), Formatted(m.ToDense()))
+	}
+
+	if err := WriteMatrixMarketCOO(&buf, &COO{}); err != ErrZeroLength {
+		t.Errorf("unexpected error for empty COO: got: %v want: %v", err, ErrZeroLength)
+	}
+}
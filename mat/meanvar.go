@@ -0,0 +1,122 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+// MeanVar accumulates the element-wise mean and variance of a stream of
+// vectors in a single pass using Welford's algorithm, without holding the
+// observations in memory. Accumulators of separate streams can be combined
+// with Merge.
+//
+// The zero value of MeanVar is an empty accumulator ready to use. The length
+// of the accumulated vectors is set by the first call to Push.
+type MeanVar struct {
+	n int
+
+	// mean holds the running mean and m2 the running
+	// sum of squared deviations from the mean.
+	mean, m2 []float64
+}
+
+// Push adds the observation x to the accumulator. Push panics with ErrShape
+// if the length of x does not match the length of previously pushed
+// observations.
+func (mv *MeanVar) Push(x Vector) {
+	if mv.n == 0 {
+		n := x.Len()
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		mv.mean = use(mv.mean, n)
+		mv.m2 = useZeroed(mv.m2, n)
+		for i := range mv.mean {
+			mv.mean[i] = x.AtVec(i)
+		}
+		mv.n = 1
+		return
+	}
+	if x.Len() != len(mv.mean) {
+		panic(ErrShape)
+	}
+	mv.n++
+	n := float64(mv.n)
+	for i, m := range mv.mean {
+		v := x.AtVec(i)
+		d := v - m
+		m += d / n
+		mv.m2[i] += d * (v - m)
+		mv.mean[i] = m
+	}
+}
+
+// Merge adds the observations accumulated by other to the receiver, as if
+// they had been pushed to the receiver. Merge panics with ErrShape if the
+// lengths of the observations held by the two accumulators differ.
+func (mv *MeanVar) Merge(other *MeanVar) {
+	switch {
+	case other.n == 0:
+		return
+	case mv.n == 0:
+		mv.n = other.n
+		mv.mean = use(mv.mean, len(other.mean))
+		copy(mv.mean, other.mean)
+		mv.m2 = use(mv.m2, len(other.m2))
+		copy(mv.m2, other.m2)
+		return
+	case len(mv.mean) != len(other.mean):
+		panic(ErrShape)
+	}
+	na, nb := float64(mv.n), float64(other.n)
+	n := na + nb
+	for i, ma := range mv.mean {
+		d := other.mean[i] - ma
+		mv.mean[i] = ma + d*nb/n
+		mv.m2[i] += other.m2[i] + d*d*na*nb/n
+	}
+	mv.n += other.n
+}
+
+// Count returns the number of observations accumulated.
+func (mv *MeanVar) Count() int { return mv.n }
+
+// Reset empties the accumulator so that it may be reused for observations
+// of a different length.
+func (mv *MeanVar) Reset() {
+	mv.n = 0
+	mv.mean = mv.mean[:0]
+	mv.m2 = mv.m2[:0]
+}
+
+// MeanVec places the element-wise mean of the accumulated observations into
+// dst. If dst is empty it is resized to the length of the observations.
+// MeanVec panics with ErrZeroLength if no observations have been pushed.
+func (mv *MeanVar) MeanVec(dst *VecDense) {
+	if mv.n == 0 {
+		panic(ErrZeroLength)
+	}
+	dst.reuseAsNonZeroed(len(mv.mean))
+	for i, m := range mv.mean {
+		dst.setVec(i, m)
+	}
+}
+
+// VarVec places the element-wise unbiased sample variance of the accumulated
+// observations into dst, normalized by one less than the number of
+// observations. The variance of a single observation is reported as zero.
+// If dst is empty it is resized to the length of the observations. VarVec
+// panics with ErrZeroLength if no observations have been pushed.
+func (mv *MeanVar) VarVec(dst *VecDense) {
+	if mv.n == 0 {
+		panic(ErrZeroLength)
+	}
+	dst.reuseAsNonZeroed(len(mv.m2))
+	if mv.n == 1 {
+		dst.Zero()
+		return
+	}
+	f := 1 / float64(mv.n-1)
+	for i, m2 := range mv.m2 {
+		dst.setVec(i, m2*f)
+	}
+}
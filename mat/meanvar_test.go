@@ -0,0 +1,98 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestMeanVar(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	const r, c = 20, 4
+	x := NewDense(r, c, nil)
+	for i := range x.mat.Data {
+		x.mat.Data[i] = 5*rnd.NormFloat64() + 100
+	}
+
+	var wantMean, wantVar VecDense
+	var s SymDense
+	s.CovarianceMatrix(x, nil)
+	wantVar.ReuseAsVec(c)
+	for j := 0; j < c; j++ {
+		wantVar.SetVec(j, s.At(j, j))
+	}
+	wantMean.ReuseAsVec(c)
+	for i := 0; i < r; i++ {
+		wantMean.AddVec(&wantMean, x.RowView(i))
+	}
+	wantMean.ScaleVec(1/float64(r), &wantMean)
+
+	var mv MeanVar
+	for i := 0; i < r; i++ {
+		mv.Push(x.RowView(i))
+	}
+	if mv.Count() != r {
+		t.Errorf("unexpected count: got %d want %d", mv.Count(), r)
+	}
+	var mean, variance VecDense
+	mv.MeanVec(&mean)
+	mv.VarVec(&variance)
+	if !EqualApprox(&mean, &wantMean, 1e-12) {
+		t.Errorf("unexpected mean: got %v want %v", Formatted(mean.T()), Formatted(wantMean.T()))
+	}
+	if !EqualApprox(&variance, &wantVar, 1e-10) {
+		t.Errorf("unexpected variance: got %v want %v", Formatted(variance.T()), Formatted(wantVar.T()))
+	}
+
+	// Merging accumulators of a split stream must match a single stream.
+	for _, split := range []int{0, 1, 7, r} {
+		var a, b MeanVar
+		for i := 0; i < split; i++ {
+			a.Push(x.RowView(i))
+		}
+		for i := split; i < r; i++ {
+			b.Push(&basicVector{m: x.RawRowView(i)})
+		}
+		a.Merge(&b)
+		if a.Count() != r {
+			t.Errorf("unexpected merged count for split %d: got %d want %d", split, a.Count(), r)
+		}
+		mean.Reset()
+		variance.Reset()
+		a.MeanVec(&mean)
+		a.VarVec(&variance)
+		if !EqualApprox(&mean, &wantMean, 1e-12) {
+			t.Errorf("unexpected merged mean for split %d: got %v want %v", split, Formatted(mean.T()), Formatted(wantMean.T()))
+		}
+		if !EqualApprox(&variance, &wantVar, 1e-10) {
+			t.Errorf("unexpected merged variance for split %d: got %v want %v", split, Formatted(variance.T()), Formatted(wantVar.T()))
+		}
+	}
+
+	// A single observation has zero variance.
+	mv.Reset()
+	mv.Push(NewVecDense(2, []float64{3, -1}))
+	variance.Reset()
+	mv.VarVec(&variance)
+	if !Equal(&variance, NewVecDense(2, nil)) {
+		t.Errorf("unexpected variance of single observation: got %v", Formatted(variance.T()))
+	}
+
+	panicked, message := panics(func() { mv.Push(NewVecDense(3, nil)) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched observation, got %q", message)
+	}
+	panicked, message = panics(func() {
+		var empty MeanVar
+		var v VecDense
+		empty.MeanVec(&v)
+	})
+	if !panicked || message != ErrZeroLength.Error() {
+		t.Errorf("expected ErrZeroLength for empty accumulator, got %q", message)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"runtime"
+	"sync/atomic"
+)
+
+// workerLimit is the maximum number of goroutines used by a single
+// parallel operation. It is accessed atomically.
+var workerLimit int64 = math.MaxInt64
+
+// SetMaxWorkers limits the number of goroutines that any single parallel
+// operation in the package, such as VecDense.MulVecParallel, may use to n.
+// If n is less than one, parallel operations are executed serially in the
+// calling goroutine. The limit is process-wide and applies to operations
+// started after the call; SetMaxWorkers is safe to call concurrently with
+// other operations. By default there is no limit beyond that requested by
+// the caller of the operation.
+func SetMaxWorkers(n int) {
+	if n < 1 {
+		n = 1
+	}
+	atomic.StoreInt64(&workerLimit, int64(n))
+}
+
+// parallelWorkers returns the number of goroutines a parallel operation
+// should use when workers were requested by the caller. A request of less
+// than one worker is a request for runtime.GOMAXPROCS(0) workers. The
+// result is capped by the limit set with SetMaxWorkers and is at least one.
+func parallelWorkers(workers int) int {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if limit := atomic.LoadInt64(&workerLimit); int64(workers) > limit {
+		workers = int(limit)
+	}
+	return workers
+}
@@ -0,0 +1,55 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSetMaxWorkers(t *testing.T) {
+	// This test does not call t.Parallel since it
+	// changes the process-wide worker limit.
+	defer atomic.StoreInt64(&workerLimit, atomic.LoadInt64(&workerLimit))
+
+	procs := runtime.GOMAXPROCS(0)
+	for _, test := range []struct {
+		limit   int
+		workers int
+		want    int
+	}{
+		{limit: 4, workers: 2, want: 2},
+		{limit: 4, workers: 8, want: 4},
+		{limit: 1, workers: 8, want: 1},
+		{limit: 0, workers: 8, want: 1},
+		{limit: -3, workers: 8, want: 1},
+		{limit: procs + 1, workers: 0, want: procs},
+		{limit: 1, workers: 0, want: 1},
+	} {
+		SetMaxWorkers(test.limit)
+		if got := parallelWorkers(test.workers); got != test.want {
+			t.Errorf("unexpected workers for limit=%d workers=%d: got %d want %d", test.limit, test.workers, got, test.want)
+		}
+	}
+
+	// Parallel operations must give the same result when run serially.
+	const r, c = 4 * minParMulVecRows, 3
+	a := NewDense(r, c, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = float64(i%7) - 3
+	}
+	b := NewVecDense(c, []float64{1, -2, 0.5})
+	var want VecDense
+	want.MulVec(a, b)
+	for _, limit := range []int{0, 2} {
+		SetMaxWorkers(limit)
+		var got VecDense
+		got.MulVecParallel(a, b, 4)
+		if !Equal(&got, &want) {
+			t.Errorf("unexpected MulVecParallel result with limit %d", limit)
+		}
+	}
+}
@@ -199,6 +199,45 @@ func putWorkspaceVec(v *VecDense) {
 	poolVec[bits(uint64(cap(v.mat.Data)))].Put(v)
 }
 
+// GetVecDense returns a *VecDense of length n with unit increment from a
+// size-stratified workspace pool. If clear is true, the elements of the
+// returned vector are zero, otherwise their values are undefined.
+// GetVecDense panics if n is not positive.
+//
+// Vectors obtained from GetVecDense may be returned to the pool with
+// PutVecDense when they are no longer needed, avoiding allocation in
+// iterative code.
+func GetVecDense(n int, clear bool) *VecDense {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic(ErrNegativeDimension)
+	}
+	v := getWorkspaceVec(n, clear)
+	v.mat.Inc = 1
+	return v
+}
+
+// PutVecDense returns a *VecDense obtained from GetVecDense to the workspace
+// pool, transferring ownership of its backing data to the pool. v must not
+// be used after the call, and no views of v or other references to its
+// backing data may be kept, since the data may be handed out again by a
+// later call to GetVecDense.
+//
+// PutVecDense cannot determine where a vector came from. Vectors whose
+// backing data capacity is not a power of two are ignored, but any other
+// vector is retained, so it is the caller's responsibility to only pass
+// vectors obtained from GetVecDense, or vectors whose data it no longer
+// holds.
+func PutVecDense(v *VecDense) {
+	c := uint64(cap(v.mat.Data))
+	if c == 0 || c != 1<<bits(c) {
+		return
+	}
+	putWorkspaceVec(v)
+}
+
 // getFloats returns a []float64 of length l and a cap that is
 // less than 2*l. If clear is true, the slice visible is zeroed.
 func getFloats(l int, clear bool) []float64 {
@@ -44,6 +44,46 @@ func TestPool(t *testing.T) {
 	}
 }
 
+func TestPoolVecDense(t *testing.T) {
+	t.Parallel()
+	for n := 1; n < 20; n++ {
+		for k := 0; k < 5; k++ {
+			work := make([]*VecDense, rand.Intn(10)+1)
+			for l := range work {
+				v := GetVecDense(n, true)
+				if v.Len() != n {
+					t.Errorf("unexpected length: got: %d want: %d", v.Len(), n)
+				}
+				if v.mat.Inc != 1 {
+					t.Errorf("unexpected increment: got: %d want: 1", v.mat.Inc)
+				}
+				for i := 0; i < n; i++ {
+					if v.AtVec(i) != 0 {
+						t.Error("unexpected non-zeroed vector returned by GetVecDense")
+						break
+					}
+				}
+				v.SetVec(0, math.NaN())
+				work[l] = v
+			}
+			for _, v := range work {
+				PutVecDense(v)
+			}
+		}
+
+		// Vectors handed over from outside the pool,
+		// and empty vectors, must not corrupt
+		// subsequent Get calls.
+		PutVecDense(NewVecDense(n, nil))
+		PutVecDense(&VecDense{})
+		v := GetVecDense(n+1, false)
+		if v.Len() != n+1 {
+			t.Errorf("unexpected length after foreign put: got: %d want: %d", v.Len(), n+1)
+		}
+		PutVecDense(v)
+	}
+}
+
 var benchmat *Dense
 
 func poolBenchmark(n, r, c int, clear bool) {
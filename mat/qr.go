@@ -264,3 +264,37 @@ func (qr *QR) SolveVecTo(dst *VecDense, trans bool, b Vector) error {
 	return qr.SolveTo(dst.asDense(), trans, bm)
 
 }
+
+// ResidualNorm returns the 2-norm of the residual of the least-squares
+// solution to A * x = b, that is ||A*x - b||_2 where x minimizes that quantity.
+// The residual is computed from the trailing m-n elements of Qᵀ * b without
+// forming x. ResidualNorm will panic if b does not have m elements or if the
+// receiver does not contain a factorization.
+func (qr *QR) ResidualNorm(b Vector) float64 {
+	if !qr.isValid() {
+		panic(badQR)
+	}
+
+	r, c := qr.qr.Dims()
+	if b.Len() != r {
+		panic(ErrShape)
+	}
+	if r == c {
+		return 0
+	}
+
+	w := getWorkspace(r, 1, false)
+	defer putWorkspace(w)
+	w.Copy(b)
+	work := []float64{0}
+	lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, w.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, w.mat, work, len(work))
+	putFloats(work)
+
+	return blas64.Nrm2(blas64.Vector{
+		N:    r - c,
+		Inc:  w.mat.Stride,
+		Data: w.mat.Data[c*w.mat.Stride:],
+	})
+}
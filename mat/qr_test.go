@@ -194,6 +194,47 @@ func TestQRSolveVecTo(t *testing.T) {
 	// TODO(btracey): Add in testOneInput when it exists.
 }
 
+func TestQRResidualNorm(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{5, 5},
+		{10, 5},
+		{20, 3},
+	} {
+		m := test.m
+		n := test.n
+		a := NewDense(m, n, nil)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a.Set(i, j, rnd.Float64())
+			}
+		}
+		b := NewVecDense(m, nil)
+		for i := 0; i < m; i++ {
+			b.SetVec(i, rnd.Float64())
+		}
+		var qr QR
+		qr.Factorize(a)
+		var x VecDense
+		err := qr.SolveVecTo(&x, false, b)
+		if err != nil {
+			t.Errorf("unexpected error from QR solve: %v", err)
+		}
+		var res VecDense
+		res.MulVec(a, &x)
+		res.SubVec(&res, b)
+		want := Norm(&res, 2)
+
+		got := qr.ResidualNorm(b)
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("unexpected residual norm for %d×%d: got: %v want: %v", m, n, got, want)
+		}
+	}
+}
+
 func TestQRSolveCondTo(t *testing.T) {
 	t.Parallel()
 	for _, test := range []*Dense{
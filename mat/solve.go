@@ -103,6 +103,53 @@ func (m *Dense) Solve(a, b Matrix) error {
 	}
 }
 
+// SolveTri solves the triangular systems of linear equations
+//  A * X = B if trans == false
+//  Aᵀ * X = B if trans == true
+// for all columns of B at once, storing the result in the receiver. The
+// receiver must be empty or n×k, where n is the order of A and k is the number
+// of columns of B.
+//
+// Unlike Solve, SolveTri does not estimate the condition number of A. It
+// panics with ErrSingular if A has non-unit diagonal and a diagonal element of
+// A is zero, and panics with ErrShape if the number of rows of B does not match
+// the order of A.
+func (m *Dense) SolveTri(a RawTriangular, trans bool, b Matrix) {
+	t := a.RawTriangular()
+	n := t.N
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if t.Diag == blas.NonUnit {
+		for i := 0; i < n; i++ {
+			if t.Data[i*t.Stride+i] == 0 {
+				panic(ErrSingular)
+			}
+		}
+	}
+	m.reuseAsNonZeroed(n, bc)
+	m.checkOverlap(generalFromTriangular(t))
+
+	switch bU, bTrans := untranspose(b); {
+	case m != bU:
+		m.checkOverlapMatrix(bU)
+		m.Copy(b)
+	case bTrans:
+		// m and b share data so Copy cannot be used directly.
+		tmp := getWorkspace(br, bc, false)
+		tmp.Copy(b)
+		m.Copy(tmp)
+		putWorkspace(tmp)
+	}
+
+	tA := blas.NoTrans
+	if trans {
+		tA = blas.Trans
+	}
+	blas64.Trsm(blas.Left, tA, 1, t, m.mat)
+}
+
 // SolveVec finds a minimum-norm solution to a system of linear equations defined
 // by the matrix a and the right-hand side column vector b. If A is singular or
 // near-singular, a Condition error is returned. See the documentation for
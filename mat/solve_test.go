@@ -305,3 +305,69 @@ func TestSolveVec(t *testing.T) {
 	}
 	testTwoInput(t, "SolveVec", &VecDense{}, method, denseComparison, legalTypesMatrixVector, legalSizeSolve, 1e-12)
 }
+
+func TestDenseSolveTri(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 3, 6} {
+		for _, k := range []int{1, 4} {
+			for _, kind := range []TriKind{Upper, Lower} {
+				a := NewTriDense(n, kind, nil)
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						if (kind == Upper && j >= i) || (kind == Lower && j <= i) {
+							a.SetTri(i, j, rnd.NormFloat64())
+						}
+					}
+					a.SetTri(i, i, 2+rnd.Float64())
+				}
+				b := NewDense(n, k, nil)
+				for i := range b.mat.Data {
+					b.mat.Data[i] = rnd.NormFloat64()
+				}
+
+				for _, trans := range []bool{false, true} {
+					var x Dense
+					x.SolveTri(a, trans, b)
+					var got Dense
+					if trans {
+						got.Mul(a.T(), &x)
+					} else {
+						got.Mul(a, &x)
+					}
+					if !EqualApprox(&got, b, 1e-12) {
+						t.Errorf("unexpected solution for n=%d k=%d kind=%v trans=%t", n, k, kind, trans)
+					}
+
+					// Transposed right-hand side and in-place solution.
+					bt := DenseCopyOf(b.T())
+					var xt Dense
+					xt.SolveTri(a, trans, bt.T())
+					if !EqualApprox(&xt, &x, 1e-14) {
+						t.Errorf("unexpected solution for transposed b with n=%d k=%d kind=%v trans=%t", n, k, kind, trans)
+					}
+					inPlace := DenseCopyOf(b)
+					inPlace.SolveTri(a, trans, inPlace)
+					if !EqualApprox(inPlace, &x, 1e-14) {
+						t.Errorf("unexpected in-place solution for n=%d k=%d kind=%v trans=%t", n, k, kind, trans)
+					}
+				}
+			}
+		}
+	}
+
+	a := NewTriDense(3, Upper, []float64{
+		1, 2, 3,
+		0, 0, 4,
+		0, 0, 5,
+	})
+	var x Dense
+	panicked, message := panics(func() { x.SolveTri(a, false, NewDense(3, 2, nil)) })
+	if !panicked || message != ErrSingular.Error() {
+		t.Errorf("expected ErrSingular for singular triangle, got %q", message)
+	}
+	panicked, message = panics(func() { x.SolveTri(a, false, NewDense(2, 2, nil)) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched b, got %q", message)
+	}
+}
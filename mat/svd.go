@@ -178,6 +178,41 @@ func (svd *SVD) Values(s []float64) []float64 {
 	return s
 }
 
+// Rank returns the numerical rank of a, the number of singular values of a
+// that are greater than tol. If tol is not positive, the tolerance
+//  tol = max(r, c) * σ_max * ε
+// is used, where r×c is the size of a, σ_max is the largest singular value of
+// a and ε = 2^-52 is the float64 machine epsilon.
+//
+// Rank computes the singular values of a copy of a, so a may be any Matrix.
+// Rank panics if the singular value decomposition fails.
+func Rank(a Matrix, tol float64) int {
+	var svd SVD
+	if !svd.Factorize(a, SVDNone) {
+		panic("mat: SVD factorization failed")
+	}
+	r, c := a.Dims()
+	return svdRank(svd.s, r, c, tol)
+}
+
+// svdRank returns the number of singular values in s, sorted in descending
+// order, that are greater than tol, using the default tolerance of Rank for
+// an r×c matrix if tol is not positive.
+func svdRank(s []float64, r, c int, tol float64) int {
+	if tol <= 0 {
+		const eps = 1.0 / (1 << 52)
+		tol = float64(max(r, c)) * s[0] * eps
+	}
+	var rank int
+	for _, v := range s {
+		if v <= tol {
+			break
+		}
+		rank++
+	}
+	return rank
+}
+
 // UTo extracts the matrix U from the singular value decomposition. The first
 // min(m,n) columns are the left singular vectors and correspond to the singular
 // values as returned from SVD.Values.
@@ -249,3 +284,53 @@ func (svd *SVD) VTo(dst *Dense) {
 	}
 	dst.Copy(tmp.T())
 }
+
+// ApproxTo computes the rank-k approximation of the factorized matrix,
+//  A_k = U_k * Σ_k * V_kᵀ
+// where U_k and V_k hold the first k left and right singular vectors and Σ_k
+// is the diagonal matrix of the k largest singular values. By the
+// Eckart–Young theorem A_k is the best rank-k approximation of A in both the
+// 2-norm and the Frobenius norm.
+//
+// If dst is empty, ApproxTo will resize dst to be m×n. When dst is non-empty,
+// ApproxTo will panic if dst is not m×n. ApproxTo will also panic if k is not
+// in [1, min(m,n)], if the receiver does not contain a successful
+// factorization, or if either U or V was not computed during factorization.
+func (svd *SVD) ApproxTo(dst *Dense, k int) {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	kind := svd.kind
+	if kind&SVDThinU == 0 && kind&SVDFullU == 0 {
+		panic("svd: u not computed during factorization")
+	}
+	if kind&SVDThinV == 0 && kind&SVDFullV == 0 {
+		panic("svd: v not computed during factorization")
+	}
+	if k < 1 || len(svd.s) < k {
+		panic(ErrIndexOutOfRange)
+	}
+	m := svd.u.Rows
+	n := svd.vt.Cols
+	dst.reuseAsNonZeroed(m, n)
+
+	// Scale the leading k columns of U by the singular values.
+	u := &Dense{
+		mat:     svd.u,
+		capRows: svd.u.Rows,
+		capCols: svd.u.Cols,
+	}
+	us := getWorkspace(m, k, false)
+	defer putWorkspace(us)
+	us.Copy(u.slice(0, m, 0, k))
+	for j, v := range svd.s[:k] {
+		blas64.Scal(v, blas64.Vector{N: m, Inc: us.mat.Stride, Data: us.mat.Data[j:]})
+	}
+
+	vt := &Dense{
+		mat:     svd.vt,
+		capRows: svd.vt.Rows,
+		capCols: svd.vt.Cols,
+	}
+	dst.Mul(us, vt.slice(0, k, 0, n))
+}
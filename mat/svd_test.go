@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"math"
 	"testing"
 
 	"golang.org/x/exp/rand"
@@ -205,6 +206,54 @@ func TestSVD(t *testing.T) {
 	}
 }
 
+func TestSVDApproxTo(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{5, 5},
+		{10, 4},
+		{4, 10},
+	} {
+		m, n := test.m, test.n
+		a := NewDense(m, n, nil)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		for _, kind := range []SVDKind{SVDThin, SVDFull} {
+			var svd SVD
+			ok := svd.Factorize(a, kind)
+			if !ok {
+				t.Fatalf("SVD failed for %d×%d", m, n)
+			}
+			s := svd.Values(nil)
+			for k := 1; k <= len(s); k++ {
+				var ak Dense
+				svd.ApproxTo(&ak, k)
+
+				// The 2-norm error of the best rank-k approximation
+				// is the (k+1)th singular value.
+				var want float64
+				if k < len(s) {
+					want = s[k]
+				}
+				var diff Dense
+				diff.Sub(a, &ak)
+				var dsvd SVD
+				dsvd.Factorize(&diff, SVDNone)
+				got := dsvd.Values(nil)[0]
+				if !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+					t.Errorf("unexpected rank-%d approximation error for %d×%d kind %d: got %v want %v",
+						k, m, n, kind, got, want)
+				}
+			}
+		}
+	}
+}
+
 func extractSVD(svd *SVD) (s []float64, u, v *Dense) {
 	u = &Dense{}
 	svd.UTo(u)
@@ -212,3 +261,133 @@ func extractSVD(svd *SVD) (s []float64, u, v *Dense) {
 	svd.VTo(v)
 	return svd.Values(nil), u, v
 }
+
+func TestRank(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	randDense := func(r, c int) *Dense {
+		m := NewDense(r, c, nil)
+		for i := range m.mat.Data {
+			m.mat.Data[i] = rnd.NormFloat64()
+		}
+		return m
+	}
+	lowRank := func(r, c, k int) *Dense {
+		var m Dense
+		m.Mul(randDense(r, k), randDense(k, c))
+		return &m
+	}
+
+	for i, test := range []struct {
+		a    Matrix
+		tol  float64
+		want int
+	}{
+		{a: NewDense(3, 3, nil), want: 0},
+		{a: NewDiagDense(4, []float64{3, 0, 1, 0}), want: 2},
+		{a: randDense(5, 5), want: 5},
+		{a: randDense(3, 7), want: 3},
+		{a: randDense(7, 3).T(), want: 3},
+		{a: lowRank(8, 6, 2), want: 2},
+		{a: lowRank(6, 9, 4), want: 4},
+		{a: NewDiagDense(3, []float64{1, 1e-3, 1e-8}), tol: 1e-6, want: 2},
+		{a: NewDiagDense(3, []float64{1, 1e-3, 1e-8}), want: 3},
+	} {
+		if got := Rank(test.a, test.tol); got != test.want {
+			t.Errorf("unexpected rank for test %d: got %d want %d", i, got, test.want)
+		}
+	}
+}
+
+func TestDensePseudoInverse(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	randDense := func(r, c int) *Dense {
+		m := NewDense(r, c, nil)
+		for i := range m.mat.Data {
+			m.mat.Data[i] = rnd.NormFloat64()
+		}
+		return m
+	}
+	lowRank := func(r, c, k int) *Dense {
+		var m Dense
+		m.Mul(randDense(r, k), randDense(k, c))
+		return &m
+	}
+
+	for i, a := range []Matrix{
+		randDense(4, 4),
+		randDense(6, 3),
+		randDense(3, 6),
+		randDense(6, 3).T(),
+		lowRank(5, 5, 2),
+		lowRank(7, 4, 3),
+		NewDiagDense(3, []float64{2, 0, 4}),
+		NewDense(2, 3, nil),
+	} {
+		r, c := a.Dims()
+		var p Dense
+		p.PseudoInverse(a, 0)
+		if pr, pc := p.Dims(); pr != c || pc != r {
+			t.Errorf("unexpected dimensions for test %d: got %d×%d want %d×%d", i, pr, pc, c, r)
+			continue
+		}
+		for _, v := range p.mat.Data {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				t.Errorf("non-finite element in pseudo-inverse for test %d", i)
+				break
+			}
+		}
+
+		// Check the four Penrose conditions.
+		var ap, pa, apa, pap Dense
+		ap.Mul(a, &p)
+		pa.Mul(&p, a)
+		apa.Mul(&ap, a)
+		pap.Mul(&pa, &p)
+		const tol = 1e-10
+		if !EqualApprox(&apa, a, tol) {
+			t.Errorf("A*A⁺*A != A for test %d", i)
+		}
+		if !EqualApprox(&pap, &p, tol) {
+			t.Errorf("A⁺*A*A⁺ != A⁺ for test %d", i)
+		}
+		if !EqualApprox(&ap, ap.T(), tol) {
+			t.Errorf("A*A⁺ not symmetric for test %d", i)
+		}
+		if !EqualApprox(&pa, pa.T(), tol) {
+			t.Errorf("A⁺*A not symmetric for test %d", i)
+		}
+	}
+
+	// For a square invertible matrix the pseudo-inverse is the inverse.
+	a := randDense(5, 5)
+	var p, inv Dense
+	p.PseudoInverse(a, 0)
+	if err := inv.Inverse(a); err != nil {
+		t.Fatalf("unexpected error inverting matrix: %v", err)
+	}
+	if !EqualApprox(&p, &inv, 1e-10) {
+		t.Errorf("pseudo-inverse does not match inverse")
+	}
+
+	// Truncation of small singular values.
+	d := NewDiagDense(3, []float64{1, 1e-3, 1e-12})
+	p.Reset()
+	p.PseudoInverse(d, 1e-6)
+	want := NewDiagDense(3, []float64{1, 1e3, 0})
+	if !EqualApprox(&p, want, 1e-9) {
+		t.Errorf("unexpected truncated pseudo-inverse:\ngot:\n%v\nwant:\n%v", Formatted(&p), Formatted(want))
+	}
+
+	// A non-empty receiver of the wrong shape is rejected before
+	// the factorization, and is left unchanged.
+	bad := NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	orig := DenseCopyOf(bad)
+	if panicked, msg := panics(func() { bad.PseudoInverse(randDense(3, 2), 0) }); !panicked || msg != ErrShape.Error() {
+		t.Errorf("expected ErrShape panic for wrong receiver shape, got: %q", msg)
+	}
+	if !Equal(bad, orig) {
+		t.Errorf("receiver modified by failed PseudoInverse")
+	}
+}
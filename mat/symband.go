@@ -85,7 +85,7 @@ func NewSymBandDense(n, k int, data []float64) *SymBandDense {
 		if n == 0 {
 			panic(ErrZeroLength)
 		}
-		panic("mat: negative dimension")
+		panic(ErrNegativeDimension)
 	}
 	if k+1 > n {
 		panic("mat: band out of range")
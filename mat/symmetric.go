@@ -9,6 +9,7 @@ import (
 
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/internal/asm/f64"
 )
 
 var (
@@ -67,7 +68,7 @@ func NewSymDense(n int, data []float64) *SymDense {
 		if n == 0 {
 			panic(ErrZeroLength)
 		}
-		panic("mat: negative dimension")
+		panic(ErrNegativeDimension)
 	}
 	if data != nil && n*n != len(data) {
 		panic(ErrShape)
@@ -387,6 +388,8 @@ func (s *SymDense) SymRankK(a Symmetric, alpha float64, x Matrix) {
 // the result into the receiver. It is equivalent to the matrix
 // multiplication
 //  s = alpha * x * x'.
+// The Gram matrix alpha * aᵀ * a can be computed without a copy by passing
+// a.T() as x.
 // In order to update an existing matrix, see SymRankOne.
 func (s *SymDense) SymOuterK(alpha float64, x Matrix) {
 	n, _ := x.Dims()
@@ -429,6 +432,107 @@ func (s *SymDense) SymOuterK(alpha float64, x Matrix) {
 	}
 }
 
+// CovarianceMatrix calculates the covariance matrix of the columns of x,
+// treating each row of x as an observation and each column as a variable,
+// and stores the result into the receiver. The receiver must either be empty
+// or have order equal to the number of columns of x.
+//
+// If weights is nil, the unbiased sample covariance is computed using
+// Bessel's correction,
+//  s = 1/(r-1) * (x - μ)ᵀ * (x - μ),
+// where r is the number of rows of x and μ holds the column means. Otherwise
+// weights must have length equal to the number of rows of x and must not
+// contain negative elements. Each weight is treated as the frequency of its
+// observation, so the means are weighted and the normalization is by the
+// sum of the weights minus one. This matches stat.CovarianceMatrix.
+//
+// The result is accumulated with a symmetric rank-k update, so it is exactly
+// symmetric.
+func (s *SymDense) CovarianceMatrix(x Matrix, weights *VecDense) {
+	r, c := x.Dims()
+	if weights != nil && weights.Len() != r {
+		panic(ErrShape)
+	}
+	if !s.IsEmpty() && s.mat.N != c {
+		panic(ErrShape)
+	}
+
+	// The rows of xt hold the centered variables.
+	xt := getWorkspace(c, r, false)
+	defer putWorkspace(xt)
+	xt.Copy(x.T())
+
+	var sqrtw []float64
+	sumw := float64(r)
+	if weights != nil {
+		sqrtw = getFloats(r, false)
+		defer putFloats(sqrtw)
+		sumw = 0
+		for i := range sqrtw {
+			w := weights.at(i)
+			if w < 0 {
+				panic("mat: negative covariance weight")
+			}
+			sumw += w
+			sqrtw[i] = math.Sqrt(w)
+		}
+	}
+	for i := 0; i < c; i++ {
+		v := xt.rawRowView(i)
+		var mean float64
+		if weights == nil {
+			mean = f64.Sum(v) / sumw
+		} else {
+			for k, xk := range v {
+				mean += weights.at(k) * xk
+			}
+			mean /= sumw
+		}
+		f64.AddConst(-mean, v)
+		if weights != nil {
+			for k := range v {
+				v[k] *= sqrtw[k]
+			}
+		}
+	}
+
+	s.SymOuterK(1/(sumw-1), xt)
+}
+
+// RBFKernel places the Gaussian radial basis function kernel matrix of the
+// rows of x into the receiver, treating each row of x as a point,
+//  s[i, j] = exp(-gamma * ‖x_i - x_j‖₂²).
+// The receiver must either be empty or have order equal to the number of
+// rows of x. Only the upper triangle is computed and the diagonal is set to
+// one, so the result is exactly symmetric.
+//
+// If the rows of x are not directly accessible they are copied once before
+// the kernel is computed.
+func (s *SymDense) RBFKernel(x Matrix, gamma float64) {
+	n, c := x.Dims()
+	s.reuseAsNonZeroed(n)
+	s.checkOverlapMatrix(x)
+
+	rm, ok := x.(RawMatrixer)
+	if !ok {
+		rm = DenseCopyOf(x)
+	}
+	xmat := rm.RawMatrix()
+	for i := 0; i < n; i++ {
+		xi := xmat.Data[i*xmat.Stride : i*xmat.Stride+c]
+		s.mat.Data[i*s.mat.Stride+i] = 1
+		for j := i + 1; j < n; j++ {
+			xj := xmat.Data[j*xmat.Stride : j*xmat.Stride+c]
+			var d2 float64
+			for k, v := range xi {
+				d := v - xj[k]
+				d2 += d * d
+			}
+			s.mat.Data[i*s.mat.Stride+j] = math.Exp(-gamma * d2)
+		}
+	}
+}
+
 // RankTwo performs a symmetric rank-two update to the matrix a with the
 // vectors x and y, which are treated as column vectors, and stores the
 // result in the receiver
@@ -6,6 +6,7 @@ package mat
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"reflect"
 	"testing"
@@ -494,6 +495,122 @@ func TestSymOuterK(t *testing.T) {
 	}
 }
 
+func TestSymOuterKGram(t *testing.T) {
+	t.Parallel()
+	a := NewDense(4, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+		10, 11, 12,
+	})
+	var s SymDense
+	s.SymOuterK(2, a.T())
+	want := NewSymDense(3, []float64{
+		332, 376, 420,
+		376, 428, 480,
+		420, 480, 540,
+	})
+	if !Equal(&s, want) {
+		t.Errorf("unexpected Gram matrix:\ngot:\n%v\nwant:\n%v", Formatted(&s), Formatted(want))
+	}
+}
+
+func TestSymRBFKernel(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10} {
+		for _, c := range []int{1, 3} {
+			x := NewDense(n, c, nil)
+			for i := range x.mat.Data {
+				x.mat.Data[i] = rnd.NormFloat64()
+			}
+			const gamma = 0.7
+			var want Dense
+			want.DistanceMatrix(x, EuclideanDistance, true)
+			want.Apply(func(_, _ int, v float64) float64 { return math.Exp(-gamma * v * v) }, &want)
+
+			for _, a := range []Matrix{x, asBasicMatrix(x)} {
+				var s SymDense
+				s.RBFKernel(a, gamma)
+				if !EqualApprox(&s, &want, 1e-14) {
+					t.Errorf("unexpected kernel for %T n=%d c=%d:\ngot:\n%v\nwant:\n%v", a, n, c, Formatted(&s), Formatted(&want))
+				}
+				var chol Cholesky
+				if !chol.Factorize(&s) {
+					t.Errorf("kernel of distinct points not positive definite for %T n=%d c=%d", a, n, c)
+				}
+			}
+		}
+	}
+
+	panicked, message := panics(func() { NewSymDense(3, nil).RBFKernel(NewDense(2, 2, nil), 1) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched receiver, got %q", message)
+	}
+}
+
+func TestSymCovarianceMatrix(t *testing.T) {
+	t.Parallel()
+	x := NewDense(3, 2, []float64{
+		1, 2,
+		3, 6,
+		5, 7,
+	})
+	var s SymDense
+	s.CovarianceMatrix(x, nil)
+	want := NewSymDense(2, []float64{
+		4, 5,
+		5, 7,
+	})
+	if !EqualApprox(&s, want, 1e-14) {
+		t.Errorf("unexpected covariance:\ngot:\n%v\nwant:\n%v", Formatted(&s), Formatted(want))
+	}
+
+	// Integer weights are equivalent to repeated observations.
+	rnd := rand.New(rand.NewSource(1))
+	const r, c = 6, 4
+	x = NewDense(r, c, nil)
+	for i := range x.mat.Data {
+		x.mat.Data[i] = rnd.NormFloat64()
+	}
+	weights := NewVecDense(r, []float64{1, 3, 0, 2, 1, 4})
+	var rep []float64
+	for i := 0; i < r; i++ {
+		for k := 0; k < int(weights.AtVec(i)); k++ {
+			rep = append(rep, x.RawRowView(i)...)
+		}
+	}
+	var wantW SymDense
+	wantW.CovarianceMatrix(NewDense(len(rep)/c, c, rep), nil)
+	sw := NewSymDense(c, nil)
+	sw.CovarianceMatrix(asBasicMatrix(x), weights)
+	if !EqualApprox(sw, &wantW, 1e-12) {
+		t.Errorf("unexpected weighted covariance:\ngot:\n%v\nwant:\n%v", Formatted(sw), Formatted(&wantW))
+	}
+	for i := 0; i < c; i++ {
+		for j := i + 1; j < c; j++ {
+			if sw.At(i, j) != sw.At(j, i) {
+				t.Errorf("covariance not exactly symmetric at (%d,%d)", i, j)
+			}
+		}
+	}
+
+	panicked, message := panics(func() {
+		var s SymDense
+		s.CovarianceMatrix(x, NewVecDense(r, []float64{1, 1, -1, 1, 1, 1}))
+	})
+	if !panicked || message != "mat: negative covariance weight" {
+		t.Errorf("expected panic for negative weight, got %q", message)
+	}
+	panicked, message = panics(func() {
+		s := NewSymDense(c+1, nil)
+		s.CovarianceMatrix(x, nil)
+	})
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched receiver, got %q", message)
+	}
+}
+
 func TestIssue250SymOuterK(t *testing.T) {
 	t.Parallel()
 	x := NewVecDense(5, []float64{1, 2, 3, 4, 5})
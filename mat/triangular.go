@@ -125,7 +125,7 @@ func NewTriDense(n int, kind TriKind, data []float64) *TriDense {
 		if n == 0 {
 			panic(ErrZeroLength)
 		}
-		panic("mat: negative dimension")
+		panic(ErrNegativeDimension)
 	}
 	if data != nil && len(data) != n*n {
 		panic(ErrShape)
@@ -201,7 +201,7 @@ func NewTriBandDense(n, k int, kind TriKind, data []float64) *TriBandDense {
 		if n == 0 {
 			panic(ErrZeroLength)
 		}
-		panic("mat: negative dimension")
+		panic(ErrNegativeDimension)
 	}
 	if k+1 > n {
 		panic("mat: band out of range")
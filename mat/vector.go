@@ -5,6 +5,11 @@
 package mat
 
 import (
+	"container/heap"
+	"math"
+	"sort"
+	"sync"
+
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
 	"gonum.org/v1/gonum/internal/asm/f64"
@@ -99,7 +104,7 @@ func NewVecDense(n int, data []float64) *VecDense {
 		if n == 0 {
 			panic(ErrZeroLength)
 		}
-		panic("mat: negative dimension")
+		panic(ErrNegativeDimension)
 	}
 	if len(data) != n && data != nil {
 		panic(ErrShape)
@@ -116,6 +121,82 @@ func NewVecDense(n int, data []float64) *VecDense {
 	}
 }
 
+// ConcatVec returns a new VecDense with unit increment holding the elements
+// of each of the vectors in vs in order. If vs is empty or all the vectors in
+// vs are empty, the returned VecDense is empty. ConcatVec panics if any of
+// the vectors in vs is nil, including a nil *VecDense.
+func ConcatVec(vs ...Vector) *VecDense {
+	var n int
+	for _, a := range vs {
+		if v, ok := a.(*VecDense); a == nil || (ok && v == nil) {
+			panic("mat: nil vector in ConcatVec")
+		}
+		n += a.Len()
+	}
+	if n == 0 {
+		return &VecDense{}
+	}
+
+	v := NewVecDense(n, nil)
+	var off int
+	for _, a := range vs {
+		l := a.Len()
+		if l == 0 {
+			continue
+		}
+		dst := blas64.Vector{N: l, Inc: 1, Data: v.mat.Data[off : off+l]}
+		if rv, ok := a.(RawVectorer); ok {
+			blas64.Copy(rv.RawVector(), dst)
+		} else {
+			for i := 0; i < l; i++ {
+				dst.Data[i] = a.AtVec(i)
+			}
+		}
+		off += l
+	}
+	return v
+}
+
+// LinspaceVec returns a new VecDense holding n evenly spaced values over the
+// closed interval [start, stop]. The first element is exactly start and, when
+// n is greater than one, the last element is exactly stop. LinspaceVec panics
+// if n is not positive.
+func LinspaceVec(start, stop float64, n int) *VecDense {
+	v := NewVecDense(n, nil)
+	if n == 1 {
+		v.mat.Data[0] = start
+		return v
+	}
+	step := (stop - start) / float64(n-1)
+	for i := range v.mat.Data {
+		v.mat.Data[i] = start + float64(i)*step
+	}
+	v.mat.Data[n-1] = stop
+	return v
+}
+
+// ArangeVec returns a new VecDense holding the values start, start+step,
+// start+2*step, ... over the half-open interval from start to stop, which
+// does not include stop. The step may be negative, in which case stop must
+// be less than start for the result to be non-empty. If no values lie in
+// the interval, the returned VecDense is empty. ArangeVec panics if step
+// is zero or if any of the parameters is NaN or infinite.
+func ArangeVec(start, stop, step float64) *VecDense {
+	if step == 0 || math.IsNaN(start) || math.IsNaN(stop) || math.IsNaN(step) ||
+		math.IsInf(start, 0) || math.IsInf(stop, 0) || math.IsInf(step, 0) {
+		panic("mat: invalid arange parameters")
+	}
+	n := math.Ceil((stop - start) / step)
+	if !(n > 0) {
+		return &VecDense{}
+	}
+	v := NewVecDense(int(n), nil)
+	for i := range v.mat.Data {
+		v.mat.Data[i] = start + float64(i)*step
+	}
+	return v
+}
+
 // SliceVec returns a new Vector that shares backing data with the receiver.
 // The returned matrix starts at i of the receiver and extends k-i elements.
 // SliceVec panics with ErrIndexOutOfRange if the slice is outside the capacity
@@ -133,6 +214,55 @@ func (v *VecDense) SliceVec(i, k int) Vector {
 	}
 }
 
+// GrowVec returns the receiver expanded by n elements. If the length of the
+// expanded vector is within the capacity of the receiver, the returned vector
+// shares backing data and increment with the receiver, and the added elements
+// hold the values already present in the backing data. Otherwise a new
+// allocation is made with unit increment, all the elements within the
+// capacity of the receiver, including those not currently visible, are copied
+// over, and the remaining elements are zero. This matches the behavior of
+// Dense.Grow. An empty receiver is grown to a zeroed vector with unit
+// increment. Note that the receiver itself is not modified during the call to
+// GrowVec.
+func (v *VecDense) GrowVec(n int) Vector {
+	if n < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	if n == 0 {
+		return v
+	}
+	r := v.mat.N + n
+	var t VecDense
+	switch {
+	case v.IsEmpty():
+		t.mat = blas64.Vector{
+			N:    r,
+			Inc:  1,
+			Data: useZeroed(v.mat.Data, r),
+		}
+	case r > v.Cap():
+		t.mat = blas64.Vector{
+			N:    r,
+			Inc:  1,
+			Data: make([]float64, r),
+		}
+		// Copy the complete vector over to the new vector,
+		// including elements not currently visible.
+		c := v.Cap()
+		blas64.Copy(
+			blas64.Vector{N: c, Inc: v.mat.Inc, Data: v.mat.Data[:(c-1)*v.mat.Inc+1]},
+			blas64.Vector{N: c, Inc: 1, Data: t.mat.Data},
+		)
+	default:
+		t.mat = blas64.Vector{
+			N:    r,
+			Inc:  v.mat.Inc,
+			Data: v.mat.Data[:(r-1)*v.mat.Inc+1],
+		}
+	}
+	return &t
+}
+
 // Dims returns the number of rows and columns in the matrix. Columns is always 1
 // for a non-Reset vector.
 func (v *VecDense) Dims() (r, c int) {
@@ -236,6 +366,81 @@ func (v *VecDense) SetRawVector(a blas64.Vector) {
 	v.mat = a
 }
 
+// SharesStorage returns whether the receiver and a have at least one element
+// in common, so that writing to an element of one may change an element of
+// the other. Only the elements reachable through the length and increment of
+// each vector are considered, so distinct columns of the same matrix do not
+// share storage even though their backing slices overlap. An empty vector
+// shares storage with no vector.
+func (v *VecDense) SharesStorage(a *VecDense) bool {
+	if v.mat.N == 0 || a.mat.N == 0 {
+		return false
+	}
+
+	// Order the vectors so that x starts no later than y.
+	x, y := v.mat, a.mat
+	off := offset(x.Data[:1], y.Data[:1])
+	if off < 0 {
+		x, y = y, x
+		off = -off
+	}
+	last := (x.N - 1) * x.Inc
+	if off > last {
+		return false
+	}
+	if x.Inc == y.Inc {
+		return off%x.Inc == 0
+	}
+	for j := 0; j < y.N; j++ {
+		p := off + j*y.Inc
+		if p > last {
+			return false
+		}
+		if p%x.Inc == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RawData returns a newly allocated slice holding the elements of the
+// receiver with unit increment. The returned slice never shares backing
+// data with the receiver, even when the receiver has unit increment.
+func (v *VecDense) RawData() []float64 {
+	data := make([]float64, v.mat.N)
+	if v.mat.N == 0 {
+		return data
+	}
+	blas64.Copy(v.mat, blas64.Vector{N: v.mat.N, Inc: 1, Data: data})
+	return data
+}
+
+// SetRawData sets the elements of the receiver from data. If the receiver is
+// empty, it adopts data as its backing slice with unit increment, so changes
+// to elements in the receiver following the call will be reflected in data.
+// Otherwise the elements of data are copied into the receiver, which must
+// have length len(data). SetRawData panics with ErrZeroLength if data is empty.
+func (v *VecDense) SetRawData(data []float64) {
+	n := len(data)
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	if v.IsEmpty() {
+		v.mat = blas64.Vector{
+			N:    n,
+			Inc:  1,
+			Data: data,
+		}
+		return
+	}
+	if n != v.mat.N {
+		panic(ErrShape)
+	}
+	src := blas64.Vector{N: n, Inc: 1, Data: data}
+	v.checkOverlap(src)
+	blas64.Copy(src, v.mat)
+}
+
 // CopyVec makes a copy of elements of a into the receiver. It is similar to the
 // built-in copy; it copies as much as the overlap between the two vectors and
 // returns the number of elements it copied.
@@ -258,6 +463,848 @@ func (v *VecDense) CopyVec(a Vector) int {
 	return n
 }
 
+// SetVecSlice copies the elements of a into the receiver starting at
+// element i, so that v[i+k] = a[k] for k in [0, a.Len()). SetVecSlice panics
+// with ErrIndexOutOfRange if the copied elements would extend beyond the
+// length of the receiver.
+//
+// The source may share backing data with the receiver, for example when it
+// is a view obtained from SliceVec of the receiver; the result is as if a
+// had been copied before writing.
+func (v *VecDense) SetVecSlice(i int, a Vector) {
+	n := a.Len()
+	if i < 0 || v.mat.N < i+n {
+		panic(ErrIndexOutOfRange)
+	}
+	if n == 0 {
+		return
+	}
+	dst := blas64.Vector{
+		N:    n,
+		Inc:  v.mat.Inc,
+		Data: v.mat.Data[i*v.mat.Inc : (i+n-1)*v.mat.Inc+1],
+	}
+	if r, ok := a.(RawVectorer); ok {
+		src := r.RawVector()
+		if overlaps(v.mat.Data, src.Data) {
+			// blas64.Copy does not handle overlapping
+			// vectors, so copy a into a workspace first.
+			w := getWorkspaceVec(n, false)
+			defer putWorkspaceVec(w)
+			blas64.Copy(src, w.mat)
+			src = w.mat
+		}
+		blas64.Copy(src, dst)
+		return
+	}
+	for k := 0; k < n; k++ {
+		dst.Data[k*dst.Inc] = a.AtVec(k)
+	}
+}
+
+// Permute permutes the elements of the receiver in place according to p.
+// If inverse is false, element i of the result is element p[i] of the
+// original vector, v[i] = v[p[i]], which is equivalent to multiplying the
+// receiver by the permutation matrix constructed by Dense.Permutation with
+// swaps equal to p. If inverse is true, the inverse permutation is applied,
+// v[p[i]] = v[i].
+//
+// Permute panics if p is not a permutation of 0..n-1, where n is the length
+// of the receiver.
+func (v *VecDense) Permute(p []int, inverse bool) {
+	n := v.mat.N
+	checkPermutation(p, n)
+	if n == 0 {
+		return
+	}
+	w := getWorkspaceVec(n, false)
+	w.CopyVec(v)
+	if inverse {
+		for i, pi := range p {
+			v.setVec(pi, w.at(i))
+		}
+	} else {
+		for i, pi := range p {
+			v.setVec(i, w.at(pi))
+		}
+	}
+	putWorkspaceVec(w)
+}
+
+// GatherVec places the elements of a selected by idx into the receiver, so
+// that v[k] = a[idx[k]] for k in [0, len(idx)). The receiver must have length
+// len(idx) or be empty, and must not share backing data with a. GatherVec
+// panics with ErrIndexOutOfRange if any index is outside the length of a.
+func (v *VecDense) GatherVec(a Vector, idx []int) {
+	n := a.Len()
+	for _, i := range idx {
+		if i < 0 || n <= i {
+			panic(ErrIndexOutOfRange)
+		}
+	}
+
+	v.reuseAsNonZeroed(len(idx))
+
+	aU, _ := untransposeExtract(a)
+	if arv, ok := aU.(*VecDense); ok {
+		amat := arv.mat
+		v.checkOverlap(amat)
+		for k, i := range idx {
+			v.setVec(k, amat.Data[i*amat.Inc])
+		}
+		return
+	}
+	for k, i := range idx {
+		v.setVec(k, a.AtVec(i))
+	}
+}
+
+// ScatterVec places the elements of a into the receiver at the positions
+// given by idx, so that v[idx[k]] = a[k] for k in [0, len(idx)). Elements of
+// the receiver not indexed by idx are unchanged, and if an index is repeated
+// the last write wins. ScatterVec panics with ErrShape if len(idx) is not
+// equal to a.Len() and with ErrIndexOutOfRange if any index is outside the
+// length of the receiver.
+func (v *VecDense) ScatterVec(idx []int, a Vector) {
+	if len(idx) != a.Len() {
+		panic(ErrShape)
+	}
+	for _, i := range idx {
+		if i < 0 || v.mat.N <= i {
+			panic(ErrIndexOutOfRange)
+		}
+	}
+
+	aU, _ := untransposeExtract(a)
+	if arv, ok := aU.(*VecDense); ok {
+		if v == arv {
+			// Permuting in place requires a copy of the source.
+			w := getWorkspaceVec(arv.mat.N, false)
+			w.CopyVec(arv)
+			defer putWorkspaceVec(w)
+			arv = w
+		}
+		amat := arv.mat
+		v.checkOverlap(amat)
+		for k, i := range idx {
+			v.setVec(i, amat.Data[k*amat.Inc])
+		}
+		return
+	}
+	for k, i := range idx {
+		v.setVec(i, a.AtVec(k))
+	}
+}
+
+// Quantile returns the p-quantile of the elements of the receiver, for p in
+// [0, 1]. The quantile is found by linear interpolation between the order
+// statistics, so that with the sorted elements x[0] <= ... <= x[n-1] and
+// h = p*(n-1), the result is x[⌊h⌋] + (h-⌊h⌋)*(x[⌊h⌋+1]-x[⌊h⌋]). A p of
+// exactly zero or one returns the minimum or maximum element respectively.
+//
+// Quantile sorts a copy of the elements and does not modify the receiver.
+// It panics if p is outside [0, 1] and with ErrZeroLength if the receiver
+// is empty.
+func (v *VecDense) Quantile(p float64) float64 {
+	if !(0 <= p && p <= 1) {
+		panic("mat: quantile out of range")
+	}
+	n := v.mat.N
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	x := getFloats(n, false)
+	defer putFloats(x)
+	blas64.Copy(v.mat, blas64.Vector{N: n, Inc: 1, Data: x})
+	sort.Float64s(x)
+
+	h := p * float64(n-1)
+	i := int(h)
+	if i == n-1 {
+		return x[i]
+	}
+	return x[i] + (h-float64(i))*(x[i+1]-x[i])
+}
+
+// Median returns the median of the elements of the receiver. It is
+// equivalent to Quantile(0.5), so for an even number of elements the mean
+// of the two central elements is returned.
+func (v *VecDense) Median() float64 {
+	return v.Quantile(0.5)
+}
+
+// GeometricMean returns the geometric mean of the elements of the receiver,
+// (Π v[i])^(1/n), computed as exp(Σ log(v[i]) / n) to avoid overflow. The
+// geometric mean is only defined for non-negative elements; GeometricMean
+// returns NaN if any element is negative and zero if any element is zero.
+// GeometricMean panics with ErrZeroLength if the receiver is empty.
+func (v *VecDense) GeometricMean() float64 {
+	n := v.mat.N
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	data, inc := v.mat.Data, v.mat.Inc
+	var sum float64
+	for i := 0; i < n; i++ {
+		x := data[i*inc]
+		if x < 0 {
+			return math.NaN()
+		}
+		sum += math.Log(x)
+	}
+	return math.Exp(sum / float64(n))
+}
+
+// HarmonicMean returns the harmonic mean of the elements of the receiver,
+// n / Σ (1/v[i]). The harmonic mean is only defined for non-negative
+// elements; HarmonicMean returns NaN if any element is negative and zero if
+// any element is zero. HarmonicMean panics with ErrZeroLength if the
+// receiver is empty.
+func (v *VecDense) HarmonicMean() float64 {
+	n := v.mat.N
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	data, inc := v.mat.Data, v.mat.Inc
+	var sum float64
+	for i := 0; i < n; i++ {
+		x := data[i*inc]
+		if x < 0 {
+			return math.NaN()
+		}
+		sum += 1 / x
+	}
+	return float64(n) / sum
+}
+
+// Argsort returns the permutation p that sorts the elements of the receiver
+// in ascending order, so that v[p[0]] <= v[p[1]] <= ... <= v[p[n-1]]. Equal
+// elements keep their relative order, and NaN values are ordered before
+// all other values as in sort.Float64s. The receiver is not modified.
+func (v *VecDense) Argsort() []int {
+	n := v.mat.N
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	data, inc := v.mat.Data, v.mat.Inc
+	sort.SliceStable(p, func(i, j int) bool {
+		a, b := data[p[i]*inc], data[p[j]*inc]
+		return a < b || (math.IsNaN(a) && !math.IsNaN(b))
+	})
+	return p
+}
+
+// TopK returns the indices of the k largest elements of the receiver in
+// descending order of value. Equal elements are ordered by ascending index,
+// and NaN values are ordered after all other values. TopK uses a bounded heap,
+// so its cost is O(n log k) rather than the O(n log n) of a full sort.
+// TopK panics with ErrShape if k is negative or greater than the length of
+// the receiver.
+func (v *VecDense) TopK(k int) []int {
+	n := v.mat.N
+	if k < 0 || n < k {
+		panic(ErrShape)
+	}
+	if k == 0 {
+		return []int{}
+	}
+	h := topKHeap{data: v.mat.Data, inc: v.mat.Inc, idx: make([]int, 0, k)}
+	for i := 0; i < n; i++ {
+		if len(h.idx) < k {
+			heap.Push(&h, i)
+			continue
+		}
+		if h.below(h.idx[0], i) {
+			h.idx[0] = i
+			heap.Fix(&h, 0)
+		}
+	}
+	p := make([]int, k)
+	for i := k - 1; i >= 0; i-- {
+		p[i] = heap.Pop(&h).(int)
+	}
+	return p
+}
+
+// topKHeap is a min-heap of vector indices ordered by rank, with the lowest
+// ranked index at the root.
+type topKHeap struct {
+	data []float64
+	inc  int
+	idx  []int
+}
+
+// below returns whether the element at index i ranks below the element at
+// index j: it is smaller, or is NaN when the other is not, or is equal and
+// has a greater index.
+func (h *topKHeap) below(i, j int) bool {
+	a, b := h.data[i*h.inc], h.data[j*h.inc]
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case aNaN != bNaN:
+		return aNaN
+	case a != b && !aNaN:
+		return a < b
+	default:
+		return i > j
+	}
+}
+
+func (h *topKHeap) Len() int           { return len(h.idx) }
+func (h *topKHeap) Less(i, j int) bool { return h.below(h.idx[i], h.idx[j]) }
+func (h *topKHeap) Swap(i, j int)      { h.idx[i], h.idx[j] = h.idx[j], h.idx[i] }
+func (h *topKHeap) Push(x interface{}) { h.idx = append(h.idx, x.(int)) }
+func (h *topKHeap) Pop() interface{} {
+	x := h.idx[len(h.idx)-1]
+	h.idx = h.idx[:len(h.idx)-1]
+	return x
+}
+
+// LogSumExp returns log(Σ exp(v[i])) computed in a numerically stable way
+// by subtracting the maximum element before exponentiation. If the maximum
+// element is infinite, LogSumExp returns it, so an input of all -Inf values
+// gives -Inf rather than NaN. LogSumExp panics with ErrZeroLength if the
+// receiver is empty.
+func (v *VecDense) LogSumExp() float64 {
+	n := v.mat.N
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	data, inc := v.mat.Data, v.mat.Inc
+	max := math.Inf(-1)
+	for i := 0; i < n; i++ {
+		x := data[i*inc]
+		if math.IsNaN(x) {
+			return x
+		}
+		if x > max {
+			max = x
+		}
+	}
+	if math.IsInf(max, 0) {
+		return max
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		sum += math.Exp(data[i*inc] - max)
+	}
+	return math.Log(sum) + max
+}
+
+// RepeatVec places count copies of a end to end into the receiver, which
+// has length a.Len()*count. RepeatVec panics if count is not positive. The
+// receiver must not share backing data with a.
+func (v *VecDense) RepeatVec(a Vector, count int) {
+	if count <= 0 {
+		panic(ErrShape)
+	}
+	n := a.Len()
+	v.reuseAsNonZeroed(n * count)
+	if rv, ok := a.(RawVectorer); ok {
+		v.checkOverlap(rv.RawVector())
+	}
+
+	first := v.SliceVec(0, n).(*VecDense)
+	first.CopyVec(a)
+	for k := 1; k < count; k++ {
+		blas64.Copy(first.mat, v.SliceVec(k*n, (k+1)*n).(*VecDense).mat)
+	}
+}
+
+// RepeatEachVec places count copies of each element of a consecutively into
+// the receiver, which has length a.Len()*count, so that v[i*count+k] = a[i]
+// for k in [0, count). RepeatEachVec panics if count is not positive. The
+// receiver must not share backing data with a.
+func (v *VecDense) RepeatEachVec(a Vector, count int) {
+	if count <= 0 {
+		panic(ErrShape)
+	}
+	n := a.Len()
+	v.reuseAsNonZeroed(n * count)
+	if rv, ok := a.(RawVectorer); ok {
+		v.checkOverlap(rv.RawVector())
+	}
+
+	for i := 0; i < n; i++ {
+		x := a.AtVec(i)
+		for k := 0; k < count; k++ {
+			v.setVec(i*count+k, x)
+		}
+	}
+}
+
+// Histogram tallies the elements of the receiver into bins equal-width bins
+// spanning [min, max] and returns dst with the counts appended. The last bin
+// is closed, so elements equal to max are counted in it. If clamp is true,
+// elements below min or above max are counted in the first or last bin
+// respectively, otherwise they are dropped. NaN elements are always dropped.
+//
+// If min equals max, the range is taken from the minimum and maximum finite
+// elements of the receiver, and infinite elements lie outside the range. If
+// all the finite elements are equal, they are counted in the first bin.
+// Histogram panics if bins is not positive or if min is greater than max.
+func (v *VecDense) Histogram(dst []float64, bins int, min, max float64, clamp bool) []float64 {
+	if bins <= 0 {
+		panic(ErrZeroLength)
+	}
+	if min > max {
+		panic("mat: histogram minimum greater than maximum")
+	}
+	n, inc := v.mat.N, v.mat.Inc
+	data := v.mat.Data
+	if min == max {
+		min, max = math.Inf(1), math.Inf(-1)
+		for i := 0; i < n; i++ {
+			x := data[i*inc]
+			if math.IsInf(x, 0) {
+				continue
+			}
+			if x < min {
+				min = x
+			}
+			if x > max {
+				max = x
+			}
+		}
+		if min > max {
+			// There are no finite elements.
+			min, max = 0, 0
+		}
+	}
+
+	l := len(dst)
+	for i := 0; i < bins; i++ {
+		dst = append(dst, 0)
+	}
+	counts := dst[l:]
+	// The bounds are halved so that the span
+	// of finite bounds cannot overflow.
+	lo := 0.5 * min
+	span := 0.5*max - lo
+	for i := 0; i < n; i++ {
+		x := data[i*inc]
+		var b int
+		switch {
+		case math.IsNaN(x):
+			continue
+		case x < min:
+			if !clamp {
+				continue
+			}
+			b = 0
+		case x >= max:
+			if x > max && !clamp {
+				continue
+			}
+			if span != 0 {
+				b = bins - 1
+			}
+		default:
+			f := float64(bins) * ((0.5*x - lo) / span)
+			switch {
+			case !(f >= 0):
+				// f is NaN when a bound is infinite.
+				b = 0
+			case f >= float64(bins):
+				// Guard against rounding at the upper edge.
+				b = bins - 1
+			default:
+				b = int(f)
+			}
+		}
+		counts[b]++
+	}
+	return dst
+}
+
+// ThresholdVec copies the elements of a into the receiver, replacing
+// elements with magnitude less than or equal to tol by zero. It returns the
+// number of non-zero elements that were replaced. ThresholdVec may be used
+// in place.
+func (v *VecDense) ThresholdVec(a Vector, tol float64) int {
+	v.reuseAsNonZeroed(a.Len())
+	if v != a {
+		v.CopyVec(a)
+	}
+	var n int
+	for i := 0; i < v.mat.N; i++ {
+		if x := v.at(i); math.Abs(x) <= tol && x != 0 {
+			v.setVec(i, 0)
+			n++
+		}
+	}
+	return n
+}
+
+// SoftThresholdVec applies the soft-thresholding operator with threshold
+// lambda to the elements of a, placing the result in the receiver. Each
+// element is shrunk toward zero by lambda,
+//  v[i] = sign(a[i]) * max(|a[i]| - lambda, 0),
+// which is the proximal operator of lambda times the L1 norm. NaN elements
+// are propagated. SoftThresholdVec may be used in place. SoftThresholdVec
+// panics if lambda is negative.
+func (v *VecDense) SoftThresholdVec(a Vector, lambda float64) {
+	if lambda < 0 {
+		panic("mat: negative soft threshold")
+	}
+	v.reuseAsNonZeroed(a.Len())
+	if v != a {
+		v.CopyVec(a)
+	}
+	for i := 0; i < v.mat.N; i++ {
+		v.setVec(i, softThreshold(v.at(i), lambda))
+	}
+}
+
+// ExpVec places the element-wise exponential of a, exp(a[i]), into the
+// receiver. ExpVec may be used in place.
+func (v *VecDense) ExpVec(a Vector) {
+	v.mapVec(a, math.Exp)
+}
+
+// LogVec places the element-wise natural logarithm of a, log(a[i]), into the
+// receiver. As for math.Log, zero elements give -Inf and negative elements
+// give NaN rather than causing a panic. LogVec may be used in place.
+func (v *VecDense) LogVec(a Vector) {
+	v.mapVec(a, math.Log)
+}
+
+// ReciprocalVec places the element-wise reciprocal of a, 1/a[i], into the
+// receiver. Zero elements give +Inf or -Inf according to their sign, and
+// infinite elements give zero of the same sign, as for IEEE division.
+// ReciprocalVec may be used in place.
+func (v *VecDense) ReciprocalVec(a Vector) {
+	v.mapVec(a, func(x float64) float64 { return 1 / x })
+}
+
+// TanhVec places the element-wise hyperbolic tangent of a, tanh(a[i]), into
+// the receiver. TanhVec may be used in place.
+func (v *VecDense) TanhVec(a Vector) {
+	v.mapVec(a, math.Tanh)
+}
+
+// SigmoidVec places the element-wise logistic sigmoid of a,
+//  1 / (1 + exp(-a[i])),
+// into the receiver. Negative elements are evaluated as
+// exp(a[i]) / (1 + exp(a[i])) so that large magnitude inputs do not
+// overflow. SigmoidVec may be used in place.
+func (v *VecDense) SigmoidVec(a Vector) {
+	v.mapVec(a, sigmoid)
+}
+
+// sigmoid returns the logistic sigmoid of x.
+func sigmoid(x float64) float64 {
+	if x < 0 {
+		e := math.Exp(x)
+		return e / (1 + e)
+	}
+	return 1 / (1 + math.Exp(-x))
+}
+
+// mapVec places fn(a[i]) into element i of the receiver. The receiver may
+// be a.
+func (v *VecDense) mapVec(a Vector, fn func(float64) float64) {
+	v.reuseAsNonZeroed(a.Len())
+	if v != a {
+		v.CopyVec(a)
+	}
+	for i := 0; i < v.mat.N; i++ {
+		v.setVec(i, fn(v.at(i)))
+	}
+}
+
+// GreaterThanVec places a mask of the elements of a that are greater than c
+// into the receiver, so that v[i] is 1 if a[i] > c and 0 otherwise. The mask
+// may be applied to a vector with MulElemVec. GreaterThanVec may be used in
+// place.
+func (v *VecDense) GreaterThanVec(a Vector, c float64) {
+	v.maskVec(a, nil, c, true)
+}
+
+// LessThanVec places a mask of the elements of a that are less than c into
+// the receiver, so that v[i] is 1 if a[i] < c and 0 otherwise. The mask may
+// be applied to a vector with MulElemVec. LessThanVec may be used in place.
+func (v *VecDense) LessThanVec(a Vector, c float64) {
+	v.maskVec(a, nil, c, false)
+}
+
+// GreaterThanElemVec places a mask of the element-wise comparison of a and b
+// into the receiver, so that v[i] is 1 if a[i] > b[i] and 0 otherwise.
+// GreaterThanElemVec may be used in place and panics with ErrShape if the
+// lengths of a and b differ.
+func (v *VecDense) GreaterThanElemVec(a, b Vector) {
+	if b.Len() != a.Len() {
+		panic(ErrShape)
+	}
+	v.maskVec(a, b, 0, true)
+}
+
+// LessThanElemVec places a mask of the element-wise comparison of a and b
+// into the receiver, so that v[i] is 1 if a[i] < b[i] and 0 otherwise.
+// LessThanElemVec may be used in place and panics with ErrShape if the
+// lengths of a and b differ.
+func (v *VecDense) LessThanElemVec(a, b Vector) {
+	if b.Len() != a.Len() {
+		panic(ErrShape)
+	}
+	v.maskVec(a, b, 0, false)
+}
+
+// WhereVec selects elements from a and b according to mask, placing the
+// result in the receiver, so that v[i] is a[i] if mask[i] != 0 and b[i]
+// otherwise. The receiver may be any of mask, a or b. WhereVec panics with
+// ErrShape if the lengths of mask, a and b differ.
+func (v *VecDense) WhereVec(mask, a, b Vector) {
+	n := mask.Len()
+	if a.Len() != n || b.Len() != n {
+		panic(ErrShape)
+	}
+	v.reuseAsNonZeroed(n)
+	for _, x := range []Vector{mask, a, b} {
+		if xU, _ := untransposeExtract(x); xU != Matrix(v) {
+			if rv, ok := xU.(*VecDense); ok {
+				v.checkOverlap(rv.mat)
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if mask.AtVec(i) != 0 {
+			v.setVec(i, a.AtVec(i))
+		} else {
+			v.setVec(i, b.AtVec(i))
+		}
+	}
+}
+
+// CopyWhereVec copies the elements of a into the receiver where the
+// corresponding element of mask is non-zero, leaving the other elements of
+// the receiver unchanged. It is equivalent to WhereVec(mask, a, v) except
+// that the receiver is never resized. The receiver may be mask or a.
+// CopyWhereVec panics with ErrShape if the lengths of the receiver, a and
+// mask differ.
+func (v *VecDense) CopyWhereVec(a, mask Vector) {
+	n := v.mat.N
+	if a.Len() != n || mask.Len() != n {
+		panic(ErrShape)
+	}
+	for _, x := range []Vector{a, mask} {
+		if xU, _ := untransposeExtract(x); xU != Matrix(v) {
+			if rv, ok := xU.(*VecDense); ok {
+				v.checkOverlap(rv.mat)
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if mask.AtVec(i) != 0 {
+			v.setVec(i, a.AtVec(i))
+		}
+	}
+}
+
+// maskVec places a 0/1 mask of the comparison of a with b into the receiver,
+// or of a with c if b is nil. Comparisons involving NaN give 0.
+func (v *VecDense) maskVec(a, b Vector, c float64, greater bool) {
+	n := a.Len()
+	v.reuseAsNonZeroed(n)
+	for _, x := range []Vector{a, b} {
+		if x == nil {
+			continue
+		}
+		if xU, _ := untransposeExtract(x); xU != Matrix(v) {
+			if rv, ok := xU.(*VecDense); ok {
+				v.checkOverlap(rv.mat)
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		x, y := a.AtVec(i), c
+		if b != nil {
+			y = b.AtVec(i)
+		}
+		var m float64
+		if (greater && x > y) || (!greater && x < y) {
+			m = 1
+		}
+		v.setVec(i, m)
+	}
+}
+
+// WeightedSumVec places the weighted sum of the vectors in vs into the
+// receiver,
+//  v = Σ_k weights[k] * vs[k].
+// All vectors in vs must have the same length. If vs is empty, the receiver
+// is Reset to an empty vector. The receiver may be one of the vectors in vs.
+//
+// WeightedSumVec panics with ErrSliceLengthMismatch if len(weights) is not
+// equal to len(vs), and with ErrShape if the lengths of the vectors differ.
+func (v *VecDense) WeightedSumVec(weights []float64, vs []Vector) {
+	if len(weights) != len(vs) {
+		panic(ErrSliceLengthMismatch)
+	}
+	if len(vs) == 0 {
+		v.Reset()
+		return
+	}
+	n := vs[0].Len()
+	for _, x := range vs[1:] {
+		if x.Len() != n {
+			panic(ErrShape)
+		}
+	}
+	v.reuseAsNonZeroed(n)
+
+	var aliased bool
+	for _, x := range vs {
+		if x == Vector(v) {
+			aliased = true
+			continue
+		}
+		if rv, ok := x.(RawVectorer); ok {
+			v.checkOverlap(rv.RawVector())
+		}
+	}
+	dst := v
+	if aliased {
+		// The receiver is an input, so accumulate
+		// into a workspace and copy the result back.
+		dst = getWorkspaceVec(n, false)
+		defer func() {
+			v.CopyVec(dst)
+			putWorkspaceVec(dst)
+		}()
+	}
+
+	dst.Zero()
+	for k, x := range vs {
+		w := weights[k]
+		if rv, ok := x.(RawVectorer); ok {
+			blas64.Axpy(w, rv.RawVector(), dst.mat)
+			continue
+		}
+		for i := 0; i < n; i++ {
+			dst.setVec(i, dst.at(i)+w*x.AtVec(i))
+		}
+	}
+}
+
+// LerpVec linearly interpolates between the vectors a and b, placing the
+// result in the receiver,
+//  v = (1-t)*a + t*b.
+// The result is exactly a when t is 0 and exactly b when t is 1, even when
+// the other vector holds infinite or NaN elements. Values of t
+// outside [0, 1] extrapolate along the line through a and b. LerpVec may be
+// used in place and panics with ErrShape if the lengths of a and b differ.
+func (v *VecDense) LerpVec(a, b Vector, t float64) {
+	n := a.Len()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+
+	v.reuseAsNonZeroed(n)
+
+	s := 1 - t
+	aU, _ := untransposeExtract(a)
+	bU, _ := untransposeExtract(b)
+	if arv, ok := aU.(*VecDense); ok {
+		if brv, ok := bU.(*VecDense); ok {
+			amat := arv.mat
+			bmat := brv.mat
+			if v != aU {
+				v.checkOverlap(amat)
+			}
+			if v != bU {
+				v.checkOverlap(bmat)
+			}
+			var ia, ib, iv int
+			for i := 0; i < n; i++ {
+				v.mat.Data[iv] = lerp(amat.Data[ia], bmat.Data[ib], s, t)
+				ia += amat.Inc
+				ib += bmat.Inc
+				iv += v.mat.Inc
+			}
+			return
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		v.setVec(i, lerp(a.AtVec(i), b.AtVec(i), s, t))
+	}
+}
+
+// lerp returns s*x + t*y where s is 1-t, returning x and y exactly
+// at the endpoints so that 0*Inf does not produce NaN.
+func lerp(x, y, s, t float64) float64 {
+	switch t {
+	case 0:
+		return x
+	case 1:
+		return y
+	}
+	return s*x + t*y
+}
+
+// DotRow returns the dot product of the receiver with row i of a without
+// forming a view of the row. DotRow panics with ErrRowAccess if i is out of
+// range and with ErrShape if the length of the receiver does not equal the
+// number of columns of a.
+func (v *VecDense) DotRow(a Matrix, i int) float64 {
+	r, c := a.Dims()
+	if uint(i) >= uint(r) {
+		panic(ErrRowAccess)
+	}
+	if v.mat.N != c {
+		panic(ErrShape)
+	}
+	if amat, trans, ok := rawGeneralOf(a); ok {
+		if trans {
+			return blas64.Dot(v.mat, blas64.Vector{N: c, Inc: amat.Stride, Data: amat.Data[i:]})
+		}
+		return blas64.Dot(v.mat, blas64.Vector{N: c, Inc: 1, Data: amat.Data[i*amat.Stride:]})
+	}
+	var sum float64
+	for j := 0; j < c; j++ {
+		sum += v.at(j) * a.At(i, j)
+	}
+	return sum
+}
+
+// DotCol returns the dot product of the receiver with column j of a without
+// forming a view of the column. DotCol panics with ErrColAccess if j is out
+// of range and with ErrShape if the length of the receiver does not equal
+// the number of rows of a.
+func (v *VecDense) DotCol(a Matrix, j int) float64 {
+	r, c := a.Dims()
+	if uint(j) >= uint(c) {
+		panic(ErrColAccess)
+	}
+	if v.mat.N != r {
+		panic(ErrShape)
+	}
+	if amat, trans, ok := rawGeneralOf(a); ok {
+		if trans {
+			return blas64.Dot(v.mat, blas64.Vector{N: r, Inc: 1, Data: amat.Data[j*amat.Stride:]})
+		}
+		return blas64.Dot(v.mat, blas64.Vector{N: r, Inc: amat.Stride, Data: amat.Data[j:]})
+	}
+	var sum float64
+	for i := 0; i < r; i++ {
+		sum += v.at(i) * a.At(i, j)
+	}
+	return sum
+}
+
+// rawGeneralOf returns the blas64.General underlying a, and whether a is
+// its transpose, if a is a RawMatrixer or the transpose of one.
+func rawGeneralOf(a Matrix) (amat blas64.General, trans, ok bool) {
+	aU, trans := untransposeExtract(a)
+	rm, ok := aU.(RawMatrixer)
+	if !ok {
+		return blas64.General{}, false, false
+	}
+	return rm.RawMatrix(), trans, true
+}
+
 // ScaleVec scales the vector a by alpha, placing the result in the receiver.
 func (v *VecDense) ScaleVec(alpha float64, a Vector) {
 	n := a.Len()
@@ -290,6 +1337,20 @@ func (v *VecDense) ScaleVec(alpha float64, a Vector) {
 	}
 }
 
+// UnitVec places the unit vector in the direction of a, a/‖a‖₂, into the
+// receiver and returns the 2-norm of a. If a is the zero vector, the receiver
+// is set to zero and UnitVec returns zero.
+func (v *VecDense) UnitVec(a Vector) (norm float64) {
+	norm = Norm(a, 2)
+	if norm == 0 {
+		v.reuseAsNonZeroed(a.Len())
+		v.Zero()
+		return 0
+	}
+	v.ScaleVec(1/norm, a)
+	return norm
+}
+
 // AddScaledVec adds the vectors a and alpha*b, placing the result in the receiver.
 func (v *VecDense) AddScaledVec(a Vector, alpha float64, b Vector) {
 	if alpha == 1 {
@@ -351,16 +1412,100 @@ func (v *VecDense) AddScaledVec(a Vector, alpha float64, b Vector) {
 			f64.AxpyInc(alpha, bmat.Data, v.mat.Data,
 				uintptr(ar), uintptr(bmat.Inc), uintptr(v.mat.Inc), 0, 0)
 		}
-	default: // v <- a + alpha * b or v <- a + alpha * v
-		if v.mat.Inc == 1 && amat.Inc == 1 && bmat.Inc == 1 {
-			// Fast path for a common case.
-			f64.AxpyUnitaryTo(v.mat.Data, alpha, bmat.Data, amat.Data)
-		} else {
-			f64.AxpyIncTo(v.mat.Data, uintptr(v.mat.Inc), 0,
-				alpha, bmat.Data, amat.Data,
-				uintptr(ar), uintptr(bmat.Inc), uintptr(amat.Inc), 0, 0)
+	default: // v <- a + alpha * b or v <- a + alpha * v
+		if v.mat.Inc == 1 && amat.Inc == 1 && bmat.Inc == 1 {
+			// Fast path for a common case.
+			f64.AxpyUnitaryTo(v.mat.Data, alpha, bmat.Data, amat.Data)
+		} else {
+			// Strided operands are handled in a single pass by the
+			// strided kernel. This is faster than a blas64.Copy
+			// followed by a blas64.Axpy, even when only a and b
+			// are strided.
+			f64.AxpyIncTo(v.mat.Data, uintptr(v.mat.Inc), 0,
+				alpha, bmat.Data, amat.Data,
+				uintptr(ar), uintptr(bmat.Inc), uintptr(amat.Inc), 0, 0)
+		}
+	}
+}
+
+// AddScaledVecNorm adds the vectors a and alpha*b, placing the result in the
+// receiver, and returns the specified norm of the result computed in the same
+// pass over the elements. Valid norms are 1, 2 and Inf, as for Norm. The
+// 2-norm is accumulated with scaling, as in blas64.Nrm2, so it does not
+// overflow for large elements.
+//
+// AddScaledVecNorm panics with ErrNormOrder if an illegal norm is specified
+// and with ErrShape if the lengths of a and b differ.
+func (v *VecDense) AddScaledVecNorm(a Vector, alpha float64, b Vector, norm float64) float64 {
+	if norm != 1 && norm != 2 && !math.IsInf(norm, 1) {
+		panic(ErrNormOrder)
+	}
+	n := a.Len()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+
+	aAt, bAt := a.AtVec, b.AtVec
+	aU, _ := untransposeExtract(a)
+	if rv, ok := aU.(*VecDense); ok {
+		amat := rv.mat
+		if v != a {
+			v.checkOverlap(amat)
+		}
+		aAt = func(i int) float64 { return amat.Data[i*amat.Inc] }
+	}
+	bU, _ := untransposeExtract(b)
+	if rv, ok := bU.(*VecDense); ok {
+		bmat := rv.mat
+		if v != b {
+			v.checkOverlap(bmat)
+		}
+		bAt = func(i int) float64 { return bmat.Data[i*bmat.Inc] }
+	}
+
+	v.reuseAsNonZeroed(n)
+
+	var (
+		sum, scale float64
+		ssq        = 1.0
+		nan, inf   bool
+	)
+	for i := 0; i < n; i++ {
+		x := aAt(i) + alpha*bAt(i)
+		v.setVec(i, x)
+		ax := math.Abs(x)
+		switch norm {
+		case 1:
+			sum += ax
+		case 2:
+			switch {
+			case ax == 0:
+			case math.IsNaN(ax):
+				nan = true
+			case math.IsInf(ax, 1):
+				inf = true
+			case scale < ax:
+				ssq = 1 + ssq*(scale/ax)*(scale/ax)
+				scale = ax
+			default:
+				ssq += (ax / scale) * (ax / scale)
+			}
+		default:
+			if ax > sum || math.IsNaN(ax) {
+				sum = ax
+			}
 		}
 	}
+	if norm != 2 {
+		return sum
+	}
+	switch {
+	case nan:
+		return math.NaN()
+	case inf:
+		return math.Inf(1)
+	}
+	return scale * math.Sqrt(ssq)
 }
 
 // AddVec adds the vectors a and b, placing the result in the receiver.
@@ -478,8 +1623,10 @@ func (v *VecDense) MulElemVec(a, b Vector) {
 
 			if v.mat.Inc == 1 && amat.Inc == 1 && bmat.Inc == 1 {
 				// Fast path for a common case.
-				for i, a := range amat.Data {
-					v.mat.Data[i] = a * bmat.Data[i]
+				dst := v.mat.Data[:ar]
+				bdata := bmat.Data[:ar]
+				for i, a := range amat.Data[:ar] {
+					dst[i] = a * bdata[i]
 				}
 				return
 			}
@@ -527,8 +1674,10 @@ func (v *VecDense) DivElemVec(a, b Vector) {
 
 			if v.mat.Inc == 1 && amat.Inc == 1 && bmat.Inc == 1 {
 				// Fast path for a common case.
-				for i, a := range amat.Data {
-					v.setVec(i, a/bmat.Data[i])
+				dst := v.mat.Data[:ar]
+				bdata := bmat.Data[:ar]
+				for i, a := range amat.Data[:ar] {
+					dst[i] = a / bdata[i]
 				}
 				return
 			}
@@ -538,6 +1687,7 @@ func (v *VecDense) DivElemVec(a, b Vector) {
 				ia += amat.Inc
 				ib += bmat.Inc
 			}
+			return
 		}
 	}
 
@@ -546,6 +1696,370 @@ func (v *VecDense) DivElemVec(a, b Vector) {
 	}
 }
 
+// DiffVec places the n-1 consecutive differences of the n-vector a,
+// a[i+1]-a[i], into the receiver. DiffVec panics if a has fewer than
+// two elements. Since the result is shorter than a, the receiver must
+// not be a, and it must not share backing data with a.
+func (v *VecDense) DiffVec(a Vector) {
+	n := a.Len()
+	if n < 2 {
+		panic(ErrShape)
+	}
+
+	v.reuseAsNonZeroed(n - 1)
+
+	aU, _ := untransposeExtract(a)
+	if arv, ok := aU.(*VecDense); ok {
+		amat := arv.mat
+		v.checkOverlap(amat)
+		if v.mat.Inc == 1 && amat.Inc == 1 {
+			// Fast path for a common case.
+			for i := range v.mat.Data[:n-1] {
+				v.mat.Data[i] = amat.Data[i+1] - amat.Data[i]
+			}
+			return
+		}
+		var ia int
+		for i := 0; i < n-1; i++ {
+			v.setVec(i, amat.Data[ia+amat.Inc]-amat.Data[ia])
+			ia += amat.Inc
+		}
+		return
+	}
+
+	for i := 0; i < n-1; i++ {
+		v.setVec(i, a.AtVec(i+1)-a.AtVec(i))
+	}
+}
+
+// WindowSumVec places the sums of each run of w consecutive elements of a
+// into the receiver, which has length a.Len()-w+1. The sums are computed
+// with a running update, so the cost is independent of w. WindowSumVec
+// panics if w is not positive or is greater than a.Len(). The receiver
+// must not share backing data with a unless w is one.
+func (v *VecDense) WindowSumVec(a Vector, w int) {
+	n := a.Len()
+	if w <= 0 || n < w {
+		panic(ErrShape)
+	}
+	m := n - w + 1
+
+	if w == 1 {
+		if v != a {
+			v.reuseAsNonZeroed(n)
+			v.CopyVec(a)
+		}
+		return
+	}
+
+	v.reuseAsNonZeroed(m)
+
+	at := a.AtVec
+	aU, _ := untransposeExtract(a)
+	if arv, ok := aU.(*VecDense); ok {
+		amat := arv.mat
+		v.checkOverlap(amat)
+		at = func(i int) float64 { return amat.Data[i*amat.Inc] }
+	}
+
+	var sum float64
+	for i := 0; i < w; i++ {
+		sum += at(i)
+	}
+	v.setVec(0, sum)
+	for i := 1; i < m; i++ {
+		sum += at(i+w-1) - at(i-1)
+		v.setVec(i, sum)
+	}
+}
+
+// WindowMeanVec places the means of each run of w consecutive elements of a
+// into the receiver, which has length a.Len()-w+1. WindowMeanVec panics under
+// the same conditions as WindowSumVec.
+func (v *VecDense) WindowMeanVec(a Vector, w int) {
+	v.WindowSumVec(a, w)
+	v.ScaleVec(1/float64(w), v)
+}
+
+// ShiftVec places a shifted by k positions into the receiver, so that element
+// i of the result is element i-k of a. A positive k shifts the elements
+// towards higher indices, as when constructing lagged series, and a negative k
+// shifts them towards lower indices. If circular is true, elements shifted off
+// one end are placed at the other, otherwise vacated positions are set to zero.
+// The receiver may be a, but must not otherwise share backing data with a.
+// ShiftVec panics with ErrZeroLength if a has zero length.
+func (v *VecDense) ShiftVec(a Vector, k int, circular bool) {
+	n := a.Len()
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	if v == a {
+		if k == 0 || (circular && k%n == 0) {
+			return
+		}
+		var restore func()
+		v, restore = v.isolatedWorkspace(a)
+		defer restore()
+	} else {
+		v.reuseAsNonZeroed(n)
+		aU, _ := untransposeExtract(a)
+		if arv, ok := aU.(*VecDense); ok {
+			v.checkOverlap(arv.mat)
+		}
+	}
+
+	if circular {
+		k %= n
+		if k < 0 {
+			k += n
+		}
+		for i := 0; i < n; i++ {
+			v.setVec((i+k)%n, a.AtVec(i))
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		if j := i - k; 0 <= j && j < n {
+			v.setVec(i, a.AtVec(j))
+		} else {
+			v.setVec(i, 0)
+		}
+	}
+}
+
+// CumMaxVec places the running maximum of a into the receiver, so that
+// v[i] = max(a[0], ..., a[i]). A NaN element propagates, so that every
+// subsequent element of the result is NaN. CumMaxVec may be used in place.
+func (v *VecDense) CumMaxVec(a Vector) {
+	v.cumExtremeVec(a, true)
+}
+
+// CumMinVec places the running minimum of a into the receiver, so that
+// v[i] = min(a[0], ..., a[i]). A NaN element propagates, so that every
+// subsequent element of the result is NaN. CumMinVec may be used in place.
+func (v *VecDense) CumMinVec(a Vector) {
+	v.cumExtremeVec(a, false)
+}
+
+func (v *VecDense) cumExtremeVec(a Vector, max bool) {
+	v.reuseAsNonZeroed(a.Len())
+	if v != a {
+		v.CopyVec(a)
+	}
+	ext := v.at(0)
+	for i := 1; i < v.mat.N; i++ {
+		x := v.at(i)
+		switch {
+		case math.IsNaN(ext):
+		case math.IsNaN(x), max && x > ext, !max && x < ext:
+			ext = x
+		}
+		v.setVec(i, ext)
+	}
+}
+
+// RowSumOf places the sum of each row of a into the receiver, which must
+// have length equal to the number of rows of a or be empty.
+func (v *VecDense) RowSumOf(a Matrix) {
+	r, c := a.Dims()
+	v.reuseAsNonZeroed(r)
+
+	aU, trans := untransposeExtract(a)
+	if rm, ok := aU.(RawMatrixer); ok {
+		raw := rm.RawMatrix()
+		checkOverlap(v.asGeneral(), raw)
+		if trans {
+			v.colSumsOf(raw)
+		} else {
+			v.rowSumsOf(raw)
+		}
+		return
+	}
+
+	for i := 0; i < r; i++ {
+		var sum float64
+		for j := 0; j < c; j++ {
+			sum += a.At(i, j)
+		}
+		v.setVec(i, sum)
+	}
+}
+
+// ColSumOf places the sum of each column of a into the receiver, which must
+// have length equal to the number of columns of a or be empty.
+func (v *VecDense) ColSumOf(a Matrix) {
+	r, c := a.Dims()
+	v.reuseAsNonZeroed(c)
+
+	aU, trans := untransposeExtract(a)
+	if rm, ok := aU.(RawMatrixer); ok {
+		raw := rm.RawMatrix()
+		checkOverlap(v.asGeneral(), raw)
+		if trans {
+			v.rowSumsOf(raw)
+		} else {
+			v.colSumsOf(raw)
+		}
+		return
+	}
+
+	for j := 0; j < c; j++ {
+		var sum float64
+		for i := 0; i < r; i++ {
+			sum += a.At(i, j)
+		}
+		v.setVec(j, sum)
+	}
+}
+
+// CentroidOf places the weighted mean of the rows of x into the receiver,
+// which must have length equal to the number of columns of x or be empty.
+// If weights is nil each row has unit weight, otherwise weights must have
+// length equal to the number of rows of x. The weighted rows are accumulated
+// in a single pass and divided by the sum of the weights. CentroidOf panics
+// with ErrShape if the length of weights does not match and panics if the
+// sum of the weights is zero.
+func (v *VecDense) CentroidOf(x Matrix, weights *VecDense) {
+	r, c := x.Dims()
+	if weights != nil && weights.mat.N != r {
+		panic(ErrShape)
+	}
+	v.reuseAsNonZeroed(c)
+
+	sum := getFloats(c, true)
+	defer putFloats(sum)
+	var sumw float64
+	aU, trans := untransposeExtract(x)
+	rm, isRaw := aU.(RawMatrixer)
+	var raw blas64.General
+	if isRaw {
+		raw = rm.RawMatrix()
+		checkOverlap(v.asGeneral(), raw)
+	}
+	for i := 0; i < r; i++ {
+		w := 1.0
+		if weights != nil {
+			w = weights.at(i)
+		}
+		sumw += w
+		switch {
+		case isRaw && !trans:
+			f64.AxpyUnitary(w, raw.Data[i*raw.Stride:i*raw.Stride+c], sum)
+		case isRaw:
+			f64.AxpyInc(w, raw.Data[i:], sum, uintptr(c), uintptr(raw.Stride), 1, 0, 0)
+		default:
+			for j := range sum {
+				sum[j] += w * x.At(i, j)
+			}
+		}
+	}
+	if sumw == 0 {
+		panic("mat: zero total weight")
+	}
+	f64.ScalUnitary(1/sumw, sum)
+	blas64.Copy(blas64.Vector{N: c, Inc: 1, Data: sum}, v.mat)
+}
+
+// RowNormOf places the specified vector norm of each row of a into the
+// receiver, which must have length equal to the number of rows of a or be
+// empty. Valid norms are 1, 2 and Inf. RowNormOf panics with ErrNormOrder
+// if an illegal norm is specified.
+func (v *VecDense) RowNormOf(a Matrix, norm float64) {
+	v.lineNormsOf(a, norm, true)
+}
+
+// ColNormOf places the specified vector norm of each column of a into the
+// receiver, which must have length equal to the number of columns of a or be
+// empty. Valid norms are 1, 2 and Inf. ColNormOf panics with ErrNormOrder
+// if an illegal norm is specified.
+func (v *VecDense) ColNormOf(a Matrix, norm float64) {
+	v.lineNormsOf(a, norm, false)
+}
+
+// lineNormsOf places the norms of the rows of a into the receiver if rows
+// is true and the norms of the columns of a otherwise.
+func (v *VecDense) lineNormsOf(a Matrix, norm float64, rows bool) {
+	if norm != 1 && norm != 2 && !math.IsInf(norm, 1) {
+		panic(ErrNormOrder)
+	}
+	r, c := a.Dims()
+	// There are n lines of length l.
+	n, l := r, c
+	if !rows {
+		n, l = c, r
+	}
+	v.reuseAsNonZeroed(n)
+
+	aU, trans := untransposeExtract(a)
+	if rm, ok := aU.(RawMatrixer); ok {
+		raw := rm.RawMatrix()
+		checkOverlap(v.asGeneral(), raw)
+		for i := 0; i < n; i++ {
+			// Line i of a is contiguous in raw when it is a row
+			// of raw, that is when exactly one of rows and trans
+			// holds.
+			x := blas64.Vector{N: l, Inc: 1, Data: raw.Data[i*raw.Stride:]}
+			if rows == trans {
+				x = blas64.Vector{N: l, Inc: raw.Stride, Data: raw.Data[i:]}
+			}
+			v.setVec(i, vectorNorm(x, norm))
+		}
+		return
+	}
+
+	work := getFloats(l, false)
+	defer putFloats(work)
+	for i := 0; i < n; i++ {
+		for k := range work {
+			if rows {
+				work[k] = a.At(i, k)
+			} else {
+				work[k] = a.At(k, i)
+			}
+		}
+		v.setVec(i, vectorNorm(blas64.Vector{N: l, Inc: 1, Data: work}, norm))
+	}
+}
+
+// vectorNorm returns the 1, 2 or Inf norm of x.
+func vectorNorm(x blas64.Vector, norm float64) float64 {
+	switch norm {
+	case 1:
+		return blas64.Asum(x)
+	case 2:
+		return blas64.Nrm2(x)
+	default:
+		return math.Abs(x.Data[blas64.Iamax(x)*x.Inc])
+	}
+}
+
+// rowSumsOf places the row sums of a into the receiver, which must
+// have length a.Rows.
+func (v *VecDense) rowSumsOf(a blas64.General) {
+	for i := 0; i < a.Rows; i++ {
+		v.setVec(i, f64.Sum(a.Data[i*a.Stride:i*a.Stride+a.Cols]))
+	}
+}
+
+// colSumsOf places the column sums of a into the receiver, which must
+// have length a.Cols. The sums are accumulated row by row in a single
+// pass over the data.
+func (v *VecDense) colSumsOf(a blas64.General) {
+	sum := v.mat.Data
+	if v.mat.Inc != 1 {
+		sum = getFloats(a.Cols, false)
+		defer putFloats(sum)
+	}
+	sum = sum[:a.Cols]
+	copy(sum, a.Data[:a.Cols])
+	for i := 1; i < a.Rows; i++ {
+		f64.AxpyUnitary(1, a.Data[i*a.Stride:i*a.Stride+a.Cols], sum)
+	}
+	if v.mat.Inc != 1 {
+		blas64.Copy(blas64.Vector{N: a.Cols, Inc: 1, Data: sum}, v.mat)
+	}
+}
+
 // MulVec computes a * b. The result is stored into the receiver.
 // MulVec panics if the number of columns in a does not equal the number of rows in b
 // or if the number of columns in b does not equal 1.
@@ -612,6 +2126,16 @@ func (v *VecDense) MulVec(a Matrix, b Vector) {
 		}
 		v.setVec(0, sum)
 		return
+	case *DiagDense:
+		// A diagonal matrix is its own transpose, so the
+		// product is the element-wise product with b.
+		d := &VecDense{mat: aU.mat}
+		if v.mat.Inc == d.mat.Inc && &v.mat.Data[0] == &d.mat.Data[0] {
+			// The receiver is a view of the diagonal.
+			d = v
+		}
+		v.MulElemVec(d, b)
+		return
 	case *SymBandDense:
 		if fast {
 			aU.checkOverlap(v.asGeneral())
@@ -632,6 +2156,7 @@ func (v *VecDense) MulVec(a Matrix, b Vector) {
 			ta = blas.Trans
 		}
 		blas64.Trmv(ta, aU.mat, v.mat)
+		return
 	case *Dense:
 		if fast {
 			aU.checkOverlap(v.asGeneral())
@@ -642,28 +2167,177 @@ func (v *VecDense) MulVec(a Matrix, b Vector) {
 			blas64.Gemv(t, 1, aU.mat, bmat, 0, v.mat)
 			return
 		}
-	default:
-		if fast {
-			for i := 0; i < r; i++ {
-				var f float64
-				for j := 0; j < c; j++ {
-					f += a.At(i, j) * bmat.Data[j*bmat.Inc]
-				}
-				v.setVec(i, f)
-			}
-			return
-		}
 	}
 
+	// Gather b into contiguous storage so that each element of b
+	// is read once rather than once for each row of a.
+	x := getFloats(c, false)
+	defer putFloats(x)
+	if fast {
+		blas64.Copy(bmat, blas64.Vector{N: c, Inc: 1, Data: x})
+	} else {
+		for j := range x {
+			x[j] = b.AtVec(j)
+		}
+	}
 	for i := 0; i < r; i++ {
 		var f float64
-		for j := 0; j < c; j++ {
-			f += a.At(i, j) * b.AtVec(j)
+		for j, xj := range x {
+			f += a.At(i, j) * xj
 		}
 		v.setVec(i, f)
 	}
 }
 
+// MulVecAccum computes a * b and adds the result to the receiver,
+//  v += a * b.
+// When a is a *Dense and b is a *VecDense that is not the receiver, the
+// product is accumulated directly with blas64.Gemv, otherwise it is formed in
+// a workspace and then added. MulVecAccum panics with ErrShape if the number
+// of columns in a does not equal the number of rows in b, if the number of
+// columns in b does not equal 1, or if the length of the receiver does not
+// equal the number of rows in a. The receiver is not resized.
+func (v *VecDense) MulVecAccum(a Matrix, b Vector) {
+	r, c := a.Dims()
+	br, bc := b.Dims()
+	if c != br || bc != 1 || v.mat.N != r {
+		panic(ErrShape)
+	}
+
+	aU, trans := untransposeExtract(a)
+	ad, ok := aU.(*Dense)
+	bv, okb := b.(*VecDense)
+	if !ok || !okb || v == b {
+		w := getWorkspaceVec(r, false)
+		defer putWorkspaceVec(w)
+		w.MulVec(a, b)
+		v.AddVec(v, w)
+		return
+	}
+
+	v.checkOverlap(bv.mat)
+	ad.checkOverlap(v.asGeneral())
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	blas64.Gemv(t, 1, ad.mat, bv.mat, 1, v.mat)
+}
+
+// MulVecTrans computes aᵀ * b and stores the result into the receiver. The
+// result is the same as that of MulVec(a.T(), b), but when a is a *Dense and
+// b is a *VecDense the product is computed directly with blas64.Gemv without
+// constructing a Transpose. MulVecTrans panics if the number of rows in a
+// does not equal the number of rows in b or if the number of columns in b
+// does not equal 1.
+func (v *VecDense) MulVecTrans(a Matrix, b Vector) {
+	r, c := a.Dims()
+	br, bc := b.Dims()
+	if r != br || bc != 1 {
+		panic(ErrShape)
+	}
+
+	am, ok := a.(*Dense)
+	bv, okb := b.(*VecDense)
+	if !ok || !okb || v == b {
+		v.MulVec(a.T(), b)
+		return
+	}
+
+	v.checkOverlap(bv.mat)
+	v.reuseAsNonZeroed(c)
+	am.checkOverlap(v.asGeneral())
+	blas64.Gemv(blas.Trans, 1, am.mat, bv.mat, 0, v.mat)
+}
+
+// ResidualVec computes the residual of the linear system a * x = b,
+//  v = b - a * x,
+// and stores the result into the receiver. When a is a Dense and x is a
+// VecDense the product is accumulated onto a copy of b with a single
+// blas64.Gemv call, otherwise a * x is formed in a workspace. The receiver
+// may be b or x. ResidualVec panics with ErrShape if the number of columns
+// of a does not equal the length of x or the number of rows of a does not
+// equal the length of b.
+func (v *VecDense) ResidualVec(a Matrix, x, b Vector) {
+	r, c := a.Dims()
+	if x.Len() != c || b.Len() != r {
+		panic(ErrShape)
+	}
+
+	aU, trans := untransposeExtract(a)
+	ad, ok := aU.(*Dense)
+	xU, _ := untransposeExtract(x)
+	xv, xok := xU.(*VecDense)
+	if !ok || !xok || v == x {
+		w := getWorkspaceVec(r, false)
+		defer putWorkspaceVec(w)
+		w.MulVec(a, x)
+		v.SubVec(b, w)
+		return
+	}
+
+	v.checkOverlap(xv.mat)
+	v.reuseAsNonZeroed(r)
+	ad.checkOverlap(v.asGeneral())
+	if v != b {
+		v.CopyVec(b)
+	}
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	blas64.Gemv(t, -1, ad.mat, xv.mat, 1, v.mat)
+}
+
+// minParMulVecRows is the smallest number of rows of a
+// that MulVecParallel will compute in a single goroutine.
+const minParMulVecRows = 256
+
+// MulVecParallel computes a * b using up to workers goroutines, storing the
+// result into the receiver. If workers is less than one, runtime.GOMAXPROCS(0)
+// workers are used. The number of workers is further limited by the value set
+// with SetMaxWorkers. The rows of the result are partitioned into contiguous
+// blocks that are each computed with blas64.Gemv, so the result is identical
+// to that of MulVec.
+//
+// MulVecParallel falls back to MulVec when a is not a *Dense, when b is not a
+// *VecDense, when the receiver is b, or when a has too few rows to benefit
+// from concurrency. MulVecParallel panics under the same conditions as MulVec.
+func (v *VecDense) MulVecParallel(a Matrix, b Vector, workers int) {
+	r, c := a.Dims()
+	br, bc := b.Dims()
+	if c != br || bc != 1 {
+		panic(ErrShape)
+	}
+	workers = min(parallelWorkers(workers), r/minParMulVecRows)
+	am, ok := a.(*Dense)
+	bv, okb := b.(*VecDense)
+	if !ok || !okb || v == b || workers < 2 {
+		v.MulVec(a, b)
+		return
+	}
+
+	v.checkOverlap(bv.mat)
+	v.reuseAsNonZeroed(r)
+	am.checkOverlap(v.asGeneral())
+
+	n := (r + workers - 1) / workers
+	var wg sync.WaitGroup
+	for i := 0; i < r; i += n {
+		k := min(i+n, r)
+		wg.Add(1)
+		go func(i, k int) {
+			defer wg.Done()
+			blas64.Gemv(blas.NoTrans, 1, am.slice(i, k, 0, c).mat, bv.mat, 0, blas64.Vector{
+				N:    k - i,
+				Inc:  v.mat.Inc,
+				Data: v.mat.Data[i*v.mat.Inc:],
+			})
+		}(i, k)
+	}
+	wg.Wait()
+}
+
 // ReuseAsVec changes the receiver if it IsEmpty() to be of size n×1.
 //
 // ReuseAsVec re-uses the backing data slice if it has sufficient capacity,
@@ -768,6 +2442,80 @@ func (v *VecDense) asGeneral() blas64.General {
 	}
 }
 
+// AsDiagDense returns a diagonal matrix with the elements of the receiver on
+// its diagonal. The returned DiagDense shares backing data with the receiver,
+// so no n×n storage is allocated and changes to the elements of either are
+// reflected in the other. AsDiagDense panics with ErrZeroLength if the
+// receiver is empty.
+func (v *VecDense) AsDiagDense() *DiagDense {
+	if v.IsEmpty() {
+		panic(ErrZeroLength)
+	}
+	return &DiagDense{mat: v.mat}
+}
+
+// AsDense returns the receiver as an n×1 column matrix. The returned Dense
+// always shares backing data with the receiver, using the increment of the
+// receiver as its stride, so changes to the elements of either are reflected
+// in the other. AsDense panics with ErrZeroLength if the receiver is empty.
+func (v *VecDense) AsDense() *Dense {
+	if v.IsEmpty() {
+		panic(ErrZeroLength)
+	}
+	return v.asDense()
+}
+
+// AsDenseRow returns the receiver as a 1×n row matrix. If the receiver has
+// unit increment, the returned Dense shares backing data with the receiver
+// and changes to the elements of either are reflected in the other.
+// Otherwise the elements are copied, since a row of a Dense must be
+// contiguous, and the returned Dense is independent of the receiver.
+// AsDenseRow panics with ErrZeroLength if the receiver is empty.
+func (v *VecDense) AsDenseRow() *Dense {
+	if v.IsEmpty() {
+		panic(ErrZeroLength)
+	}
+	n := v.mat.N
+	if v.mat.Inc == 1 {
+		return NewDense(1, n, v.mat.Data[:n])
+	}
+	m := NewDense(1, n, nil)
+	blas64.Copy(v.mat, blas64.Vector{N: n, Inc: 1, Data: m.mat.Data})
+	return m
+}
+
+// Flatten copies the elements of a into the receiver in row-major order, so
+// that v[i*c+j] = a[i, j] where c is the number of columns of a. The
+// receiver must be empty or have length equal to the number of elements of
+// a. The result is a copy rather than a view of a since a matrix with a
+// general stride cannot be represented as a vector with a single increment.
+func (v *VecDense) Flatten(a Matrix) {
+	r, c := a.Dims()
+	v.reuseAsNonZeroed(r * c)
+
+	if rm, ok := a.(RawMatrixer); ok {
+		amat := rm.RawMatrix()
+		checkOverlap(v.asGeneral(), amat)
+		if amat.Stride == c {
+			blas64.Copy(blas64.Vector{N: r * c, Inc: 1, Data: amat.Data[:r*c]}, v.mat)
+			return
+		}
+		for i := 0; i < r; i++ {
+			blas64.Copy(
+				blas64.Vector{N: c, Inc: 1, Data: amat.Data[i*amat.Stride : i*amat.Stride+c]},
+				blas64.Vector{N: c, Inc: v.mat.Inc, Data: v.mat.Data[i*c*v.mat.Inc:]},
+			)
+		}
+		return
+	}
+
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v.setVec(i*c+j, a.At(i, j))
+		}
+	}
+}
+
 // ColViewOf reflects the column j of the RawMatrixer m, into the receiver
 // backed by the same underlying data. The receiver must either be empty
 // have length equal to the number of rows of m.
@@ -0,0 +1,207 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "gonum.org/v1/gonum/blas/blas32"
+
+// VecDense32 represents a column vector of float32 values. It is intended
+// for storing and transporting large vectors at half the memory cost of a
+// VecDense, and provides only the core element-wise and reduction operations.
+//
+// Values held in a VecDense32 have roughly 7 significant decimal digits of
+// precision and a maximum magnitude of about 3.4e38, compared with about 16
+// digits and 1.8e308 for float64. Conversion from a VecDense with ToFloat32
+// rounds each element to the nearest float32, and elements too large in
+// magnitude become infinite. Accumulations of many values, as in Dot, are
+// more prone to rounding error than their float64 counterparts.
+//
+// Unlike VecDense, VecDense32 does not implement the Matrix interface.
+// Operations on VecDense32 do not check for partially overlapping data
+// between the receiver and the operands; the receiver may only be identical
+// to an operand or entirely separate from it.
+type VecDense32 struct {
+	mat blas32.Vector
+	// VecDense32 must have positive increment in this package.
+}
+
+// NewVecDense32 creates a new VecDense32 of length n. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n, data is
+// used as the backing slice, and changes to the elements of the returned
+// VecDense32 will be reflected in data. If neither of these is true,
+// NewVecDense32 will panic. NewVecDense32 will panic if n is zero.
+func NewVecDense32(n int, data []float32) *VecDense32 {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic(ErrNegativeDimension)
+	}
+	if len(data) != n && data != nil {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]float32, n)
+	}
+	return &VecDense32{
+		mat: blas32.Vector{
+			N:    n,
+			Inc:  1,
+			Data: data,
+		},
+	}
+}
+
+// ToFloat32 returns a new VecDense32 holding the elements of the receiver
+// rounded to float32.
+func (v *VecDense) ToFloat32() *VecDense32 {
+	if v.IsEmpty() {
+		return &VecDense32{}
+	}
+	w := NewVecDense32(v.mat.N, nil)
+	for i := range w.mat.Data {
+		w.mat.Data[i] = float32(v.mat.Data[i*v.mat.Inc])
+	}
+	return w
+}
+
+// ToFloat64 returns a new VecDense holding the elements of the receiver.
+// The conversion is exact.
+func (v *VecDense32) ToFloat64() *VecDense {
+	if v.IsEmpty() {
+		return &VecDense{}
+	}
+	w := NewVecDense(v.mat.N, nil)
+	for i := range w.mat.Data {
+		w.mat.Data[i] = float64(v.mat.Data[i*v.mat.Inc])
+	}
+	return w
+}
+
+// Len returns the length of the vector.
+func (v *VecDense32) Len() int {
+	return v.mat.N
+}
+
+// Reset zeros the length of the vector so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// Reset should not be used when the vector shares backing data.
+// See the Reseter interface for more information.
+func (v *VecDense32) Reset() {
+	// No change of Inc or N to 0 may be
+	// made unless both are set to 0.
+	v.mat.Inc = 0
+	v.mat.N = 0
+	v.mat.Data = v.mat.Data[:0]
+}
+
+// IsEmpty returns whether the receiver is empty. Empty vectors can be the
+// receiver for size-restricted operations. The receiver can be emptied using
+// Reset.
+func (v *VecDense32) IsEmpty() bool {
+	return v.mat.Inc == 0
+}
+
+// RawVector returns the underlying blas32.Vector used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned blas32.Vector.
+func (v *VecDense32) RawVector() blas32.Vector {
+	return v.mat
+}
+
+// AddVec adds the vectors a and b, placing the result in the receiver.
+func (v *VecDense32) AddVec(a, b *VecDense32) {
+	v.addScaledVec(a, 1, b)
+}
+
+// SubVec subtracts the vector b from a, placing the result in the receiver.
+func (v *VecDense32) SubVec(a, b *VecDense32) {
+	v.addScaledVec(a, -1, b)
+}
+
+func (v *VecDense32) addScaledVec(a *VecDense32, alpha float32, b *VecDense32) {
+	n := a.Len()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	v.reuseAsNonZeroed(n)
+	switch {
+	case v == a:
+		blas32.Axpy(alpha, b.mat, v.mat)
+	case v == b:
+		// v = a + alpha*v computed as v = alpha*v + a.
+		blas32.Scal(alpha, v.mat)
+		blas32.Axpy(1, a.mat, v.mat)
+	default:
+		blas32.Copy(a.mat, v.mat)
+		blas32.Axpy(alpha, b.mat, v.mat)
+	}
+}
+
+// ScaleVec scales the vector a by alpha, placing the result in the receiver.
+func (v *VecDense32) ScaleVec(alpha float32, a *VecDense32) {
+	v.reuseAsNonZeroed(a.Len())
+	if v != a {
+		blas32.Copy(a.mat, v.mat)
+	}
+	blas32.Scal(alpha, v.mat)
+}
+
+// MulElemVec performs element-wise multiplication of a and b, placing the
+// result in the receiver.
+func (v *VecDense32) MulElemVec(a, b *VecDense32) {
+	n := a.Len()
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	v.reuseAsNonZeroed(n)
+	for i := 0; i < n; i++ {
+		v.mat.Data[i*v.mat.Inc] = a.mat.Data[i*a.mat.Inc] * b.mat.Data[i*b.mat.Inc]
+	}
+}
+
+// Dot32 returns the sum of the element-wise product of a and b. The
+// products are accumulated in float64 to reduce rounding error.
+// Dot32 panics with ErrShape if the vector sizes are unequal.
+func Dot32(a, b *VecDense32) float64 {
+	if a.Len() != b.Len() {
+		panic(ErrShape)
+	}
+	if a.IsEmpty() {
+		return 0
+	}
+	return blas32.DDot(a.mat, b.mat)
+}
+
+// Norm returns the Euclidean norm of the receiver. Norm panics with
+// ErrZeroLength if the receiver is empty.
+func (v *VecDense32) Norm() float32 {
+	if v.IsEmpty() {
+		panic(ErrZeroLength)
+	}
+	return blas32.Nrm2(v.mat)
+}
+
+// reuseAsNonZeroed resizes an empty vector to a r×1 vector,
+// or checks that a non-empty vector is r×1.
+func (v *VecDense32) reuseAsNonZeroed(r int) {
+	if r == 0 {
+		panic(ErrZeroLength)
+	}
+	if v.IsEmpty() {
+		if cap(v.mat.Data) < r {
+			v.mat.Data = make([]float32, r)
+		}
+		v.mat = blas32.Vector{
+			N:    r,
+			Inc:  1,
+			Data: v.mat.Data[:r],
+		}
+		return
+	}
+	if r != v.mat.N {
+		panic(ErrShape)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestVecDense32Conversion(t *testing.T) {
+	t.Parallel()
+	v := NewDense(3, 2, []float64{1, 0, 0.1, 0, 1e40, 0}).ColView(0).(*VecDense)
+	w := v.ToFloat32()
+	want := []float32{1, 0.1, float32(math.Inf(1))}
+	if !reflect.DeepEqual(w.RawVector().Data, want) {
+		t.Errorf("unexpected float32 data: got: %v want: %v", w.RawVector().Data, want)
+	}
+	back := w.ToFloat64()
+	wantBack := NewVecDense(3, []float64{1, float64(float32(0.1)), math.Inf(1)})
+	if !Equal(back, wantBack) {
+		t.Errorf("unexpected float64 data: got: %v want: %v", back.RawVector().Data, wantBack.RawVector().Data)
+	}
+	if !new(VecDense).ToFloat32().IsEmpty() || !new(VecDense32).ToFloat64().IsEmpty() {
+		t.Error("expected empty conversion of empty vector")
+	}
+}
+
+func TestVecDense32Arithmetic(t *testing.T) {
+	t.Parallel()
+	a := NewVecDense32(3, []float32{1, 2, 3})
+	b := NewVecDense32(3, []float32{4, 5, 6})
+
+	for _, test := range []struct {
+		name string
+		fn   func(v *VecDense32)
+		want []float32
+	}{
+		{name: "AddVec", fn: func(v *VecDense32) { v.AddVec(a, b) }, want: []float32{5, 7, 9}},
+		{name: "SubVec", fn: func(v *VecDense32) { v.SubVec(a, b) }, want: []float32{-3, -3, -3}},
+		{name: "ScaleVec", fn: func(v *VecDense32) { v.ScaleVec(2, a) }, want: []float32{2, 4, 6}},
+		{name: "MulElemVec", fn: func(v *VecDense32) { v.MulElemVec(a, b) }, want: []float32{4, 10, 18}},
+	} {
+		var v VecDense32
+		test.fn(&v)
+		if !reflect.DeepEqual(v.RawVector().Data, test.want) {
+			t.Errorf("unexpected %s result: got: %v want: %v", test.name, v.RawVector().Data, test.want)
+		}
+	}
+
+	// Aliased receivers.
+	v := NewVecDense32(3, []float32{1, 2, 3})
+	v.SubVec(b, v)
+	if want := []float32{3, 3, 3}; !reflect.DeepEqual(v.RawVector().Data, want) {
+		t.Errorf("unexpected aliased SubVec result: got: %v want: %v", v.RawVector().Data, want)
+	}
+	v.AddVec(v, v)
+	if want := []float32{6, 6, 6}; !reflect.DeepEqual(v.RawVector().Data, want) {
+		t.Errorf("unexpected aliased AddVec result: got: %v want: %v", v.RawVector().Data, want)
+	}
+
+	if got := Dot32(a, b); got != 32 {
+		t.Errorf("unexpected Dot32 result: got: %v want: 32", got)
+	}
+	if got := NewVecDense32(2, []float32{3, 4}).Norm(); got != 5 {
+		t.Errorf("unexpected Norm result: got: %v want: 5", got)
+	}
+
+	if panicked, _ := panics(func() { new(VecDense32).AddVec(a, NewVecDense32(2, nil)) }); !panicked {
+		t.Error("expected panic for length mismatch")
+	}
+	if panicked, _ := panics(func() { a.AtVec(3) }); !panicked {
+		t.Error("expected panic for out of range access")
+	}
+}
@@ -5,12 +5,15 @@
 package mat
 
 import (
+	"math"
 	"reflect"
+	"sort"
 	"testing"
 
 	"golang.org/x/exp/rand"
 
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
 )
 
 func TestNewVecDense(t *testing.T) {
@@ -57,6 +60,120 @@ func TestNewVecDense(t *testing.T) {
 	}
 }
 
+func TestConcatVec(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		vs   []Vector
+		want []float64
+	}{
+		{vs: nil, want: nil},
+		{vs: []Vector{&VecDense{}}, want: nil},
+		{vs: []Vector{NewVecDense(2, []float64{1, 2})}, want: []float64{1, 2}},
+		{
+			vs: []Vector{
+				NewVecDense(2, []float64{1, 2}),
+				&VecDense{},
+				NewDense(2, 2, []float64{3, 0, 4, 0}).ColView(0),
+				&basicVector{m: []float64{5}},
+				NewVecDense(1, []float64{6}),
+			},
+			want: []float64{1, 2, 3, 4, 5, 6},
+		},
+	} {
+		got := ConcatVec(test.vs...)
+		if test.want == nil {
+			if !got.IsEmpty() {
+				t.Errorf("test %d: expected empty result, got length %d", i, got.Len())
+			}
+			continue
+		}
+		if want := NewVecDense(len(test.want), test.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("test %d: unexpected result: got: %v want: %v", i, got.RawVector(), want.RawVector())
+		}
+	}
+
+	if panicked, _ := panics(func() { ConcatVec(NewVecDense(1, nil), nil) }); !panicked {
+		t.Error("expected panic for nil vector")
+	}
+	var nilVec *VecDense
+	if panicked, msg := panics(func() { ConcatVec(NewVecDense(1, nil), nilVec) }); !panicked || msg != "mat: nil vector in ConcatVec" {
+		t.Errorf("expected nil vector panic for nil *VecDense, got: %q", msg)
+	}
+}
+
+func TestLinspaceVec(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		start, stop float64
+		n           int
+		want        []float64
+	}{
+		{start: 3, stop: 5, n: 1, want: []float64{3}},
+		{start: 0, stop: 1, n: 2, want: []float64{0, 1}},
+		{start: 0, stop: 1, n: 5, want: []float64{0, 0.25, 0.5, 0.75, 1}},
+		{start: 1, stop: -1, n: 3, want: []float64{1, 0, -1}},
+		{start: 0, stop: 0.3, n: 4, want: []float64{0, 0.1, 0.2, 0.3}},
+	} {
+		got := LinspaceVec(test.start, test.stop, test.n)
+		want := NewVecDense(len(test.want), test.want)
+		if !EqualApprox(got, want, 1e-15) {
+			t.Errorf("test %d: unexpected result: got: %v want: %v", i, got.RawData(), test.want)
+		}
+		if n := got.Len(); n > 1 && got.AtVec(n-1) != test.stop {
+			t.Errorf("test %d: last element not exactly stop: got: %v want: %v", i, got.AtVec(n-1), test.stop)
+		}
+	}
+	for _, n := range []int{-1, 0} {
+		if panicked, _ := panics(func() { LinspaceVec(0, 1, n) }); !panicked {
+			t.Errorf("expected panic for n=%d", n)
+		}
+	}
+}
+
+func TestArangeVec(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		start, stop, step float64
+		want              []float64
+	}{
+		{start: 0, stop: 3, step: 1, want: []float64{0, 1, 2}},
+		{start: 0, stop: 3.5, step: 1, want: []float64{0, 1, 2, 3}},
+		{start: 1, stop: 2, step: 0.25, want: []float64{1, 1.25, 1.5, 1.75}},
+		{start: 3, stop: 0, step: -1, want: []float64{3, 2, 1}},
+		{start: 0, stop: 0, step: 1, want: nil},
+		{start: 0, stop: 3, step: -1, want: nil},
+		{start: 3, stop: 0, step: 1, want: nil},
+	} {
+		got := ArangeVec(test.start, test.stop, test.step)
+		if test.want == nil {
+			if !got.IsEmpty() {
+				t.Errorf("test %d: expected empty result, got: %v", i, got.RawData())
+			}
+			continue
+		}
+		want := NewVecDense(len(test.want), test.want)
+		if !Equal(got, want) {
+			t.Errorf("test %d: unexpected result: got: %v want: %v", i, got.RawData(), test.want)
+		}
+	}
+	for _, test := range []struct {
+		start, stop, step float64
+	}{
+		{start: 0, stop: 1, step: 0},
+		{start: 0, stop: 1, step: math.NaN()},
+		{start: math.NaN(), stop: 1, step: 1},
+		{start: 0, stop: math.Inf(1), step: 1},
+		{start: math.Inf(-1), stop: 0, step: 1},
+		{start: 0, stop: 1, step: math.Inf(1)},
+		{start: 0, stop: -1, step: math.Inf(-1)},
+	} {
+		panicked, msg := panics(func() { ArangeVec(test.start, test.stop, test.step) })
+		if !panicked || msg != "mat: invalid arange parameters" {
+			t.Errorf("expected invalid parameters panic for start=%v stop=%v step=%v, got: %q", test.start, test.stop, test.step, msg)
+		}
+	}
+}
+
 func TestCap(t *testing.T) {
 	t.Parallel()
 	for i, test := range []struct {
@@ -241,6 +358,205 @@ func TestVecDenseMul(t *testing.T) {
 	testTwoInput(t, "MulVec", &VecDense{}, method, denseComparison, legalTypesMatrixVector, legalSizeMulVec, 1e-14)
 }
 
+func TestVecDenseMulVecDiagonal(t *testing.T) {
+	t.Parallel()
+	diag := NewVecDense(3, []float64{2, -1, 0.5})
+	b := NewVecDense(3, []float64{1, 2, 4})
+	want := NewVecDense(3, []float64{2, -2, 2})
+	for i, a := range []Matrix{
+		NewDiagDense(3, []float64{2, -1, 0.5}),
+		NewDiagDense(3, []float64{2, -1, 0.5}).T(),
+		NewDense(3, 2, []float64{2, 0, -1, 0, 0.5, 0}).ColView(0).(*VecDense).AsDiagDense(),
+	} {
+		var v VecDense
+		v.MulVec(a, b)
+		if !Equal(&v, want) {
+			t.Errorf("test %d: unexpected result: got: %v want: %v", i, v.RawVector().Data, want.RawVector().Data)
+		}
+	}
+
+	// Receiver is the vector backing the diagonal.
+	v := NewVecDense(3, nil)
+	v.CopyVec(diag)
+	v.MulVec(v.AsDiagDense(), b)
+	if !Equal(v, want) {
+		t.Errorf("unexpected result for diagonal view receiver: got: %v want: %v", v.RawVector().Data, want.RawVector().Data)
+	}
+
+	// Receiver is b.
+	v.CopyVec(b)
+	v.MulVec(diag.AsDiagDense(), v)
+	if !Equal(v, want) {
+		t.Errorf("unexpected result for aliased b: got: %v want: %v", v.RawVector().Data, want.RawVector().Data)
+	}
+}
+
+func TestVecDenseMulVecAccum(t *testing.T) {
+	t.Parallel()
+	src := rand.NewSource(1)
+	rnd := rand.New(src)
+	for _, test := range []struct {
+		r, c int
+		inc  int
+	}{
+		{r: 1, c: 1, inc: 1},
+		{r: 3, c: 3, inc: 1},
+		{r: 5, c: 2, inc: 2},
+		{r: 2, c: 5, inc: 3},
+	} {
+		a := NewDense(test.r, test.c, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		b := randVecDense(test.c, test.inc, 1, src)
+		bT := randVecDense(test.r, test.inc, 1, src)
+		for _, in := range []struct {
+			name string
+			a    Matrix
+			b    Vector
+		}{
+			{name: "Dense", a: a, b: b},
+			{name: "transposed Dense", a: a.T(), b: bT},
+			{name: "basic matrix", a: asBasicMatrix(a), b: b},
+			{name: "basic vector", a: a, b: &basicVector{m: b.RawData()}},
+		} {
+			r, _ := in.a.Dims()
+			v := randVecDense(r, test.inc, 1, src)
+			var want VecDense
+			want.MulVec(in.a, in.b)
+			want.AddVec(&want, v)
+			v.MulVecAccum(in.a, in.b)
+			if !EqualApprox(v, &want, 1e-14) {
+				t.Errorf("unexpected result for %s %d×%d inc=%d:\ngot: %v\nwant:%v", in.name, test.r, test.c, test.inc, Formatted(v.T()), Formatted(want.T()))
+			}
+		}
+
+		if test.r == test.c {
+			// The receiver may be b.
+			var want VecDense
+			want.MulVec(a, b)
+			want.AddVec(&want, b)
+			bc := VecDenseCopyOf(b)
+			bc.MulVecAccum(a, bc)
+			if !EqualApprox(bc, &want, 1e-14) {
+				t.Errorf("unexpected result with receiver b %d×%d inc=%d", test.r, test.c, test.inc)
+			}
+		}
+	}
+
+	for _, v := range []*VecDense{{}, NewVecDense(3, nil)} {
+		panicked, message := panics(func() { v.MulVecAccum(NewDense(2, 3, nil), NewVecDense(3, nil)) })
+		if !panicked || message != ErrShape.Error() {
+			t.Errorf("expected ErrShape for receiver of length %d, got %q", v.Len(), message)
+		}
+	}
+}
+
+func TestVecDenseMulVecTrans(t *testing.T) {
+	t.Parallel()
+	src := rand.NewSource(1)
+	rnd := rand.New(src)
+	for _, test := range []struct {
+		r, c int
+		inc  int
+	}{
+		{r: 1, c: 1, inc: 1},
+		{r: 3, c: 3, inc: 1},
+		{r: 5, c: 2, inc: 2},
+		{r: 2, c: 5, inc: 3},
+	} {
+		a := NewDense(test.r, test.c, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		b := randVecDense(test.r, test.inc, 1, src)
+		for _, m := range []Matrix{a, asBasicMatrix(a), a.T().T()} {
+			for _, bv := range []Vector{b, &basicVector{m: b.RawData()}} {
+				var want, got VecDense
+				want.MulVec(m.T(), bv)
+				got.MulVecTrans(m, bv)
+				if !Equal(&got, &want) {
+					t.Errorf("unexpected result for %T×%T %d×%d inc=%d:\ngot: %v\nwant:%v", m, bv, test.r, test.c, test.inc, Formatted(got.T()), Formatted(want.T()))
+				}
+			}
+		}
+
+		strided := randVecDense(test.c, test.inc, 1, src)
+		var want VecDense
+		want.MulVec(a.T(), b)
+		strided.MulVecTrans(a, b)
+		if !Equal(strided, &want) {
+			t.Errorf("unexpected result for strided receiver %d×%d inc=%d", test.r, test.c, test.inc)
+		}
+
+		if test.r == test.c {
+			// The receiver may be b.
+			bc := VecDenseCopyOf(b)
+			bc.MulVecTrans(a, bc)
+			if !Equal(bc, &want) {
+				t.Errorf("unexpected result with receiver b %d×%d inc=%d", test.r, test.c, test.inc)
+			}
+		}
+	}
+
+	panicked, message := panics(func() { new(VecDense).MulVecTrans(NewDense(2, 3, nil), NewVecDense(3, nil)) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched b, got %q", message)
+	}
+}
+
+func TestVecDenseMulVecParallel(t *testing.T) {
+	t.Parallel()
+	src := rand.NewSource(1)
+	for _, test := range []struct {
+		r, c    int
+		inc     int
+		workers int
+	}{
+		{r: 10, c: 10, inc: 1, workers: 4},
+		{r: 1000, c: 30, inc: 1, workers: 0},
+		{r: 1000, c: 30, inc: 1, workers: 1},
+		{r: 1000, c: 30, inc: 1, workers: 3},
+		{r: 1031, c: 17, inc: 1, workers: 4},
+		{r: 1031, c: 17, inc: 3, workers: 4},
+		{r: 4096, c: 5, inc: 2, workers: 16},
+	} {
+		rnd := rand.New(src)
+		a := NewDense(test.r, test.c, nil)
+		for i := 0; i < test.r; i++ {
+			for j := 0; j < test.c; j++ {
+				a.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		b := randVecDense(test.c, test.inc, 1, src)
+
+		var want VecDense
+		want.MulVec(a, b)
+
+		var got VecDense
+		got.MulVecParallel(a, b, test.workers)
+		if !Equal(&got, &want) {
+			t.Errorf("unexpected result for %d×%d workers=%d inc=%d", test.r, test.c, test.workers, test.inc)
+		}
+
+		strided := NewVecDense(test.r*test.inc, nil).SliceVec(0, test.r).(*VecDense)
+		strided.mat.Inc = test.inc
+		strided.mat.Data = strided.mat.Data[:(test.r-1)*test.inc+1]
+		strided.MulVecParallel(a, b, test.workers)
+		if !Equal(strided, &want) {
+			t.Errorf("unexpected result for strided receiver %d×%d workers=%d inc=%d", test.r, test.c, test.workers, test.inc)
+		}
+
+		bT := randVecDense(test.r, test.inc, 1, src)
+		var wantT, gotT VecDense
+		wantT.MulVec(a.T(), bT)
+		gotT.MulVecParallel(a.T(), bT, test.workers)
+		if !Equal(&gotT, &wantT) {
+			t.Errorf("unexpected result for transposed %d×%d workers=%d inc=%d", test.r, test.c, test.workers, test.inc)
+		}
+	}
+}
+
 func TestVecDenseScale(t *testing.T) {
 	t.Parallel()
 	for i, test := range []struct {
@@ -316,132 +632,1879 @@ func TestVecDenseScale(t *testing.T) {
 	}
 }
 
-func TestCopyVec(t *testing.T) {
+func TestVecDenseUnitVec(t *testing.T) {
 	t.Parallel()
 	for i, test := range []struct {
-		src   *VecDense
-		dst   *VecDense
-		want  *VecDense
-		wantN int
+		a        Vector
+		want     *VecDense
+		wantNorm float64
 	}{
-		{src: NewVecDense(1, nil), dst: NewVecDense(1, nil), want: NewVecDense(1, nil), wantN: 1},
-		{src: NewVecDense(3, []float64{1, 2, 3}), dst: NewVecDense(2, []float64{-1, -2}), want: NewVecDense(2, []float64{1, 2}), wantN: 2},
-		{src: NewVecDense(2, []float64{1, 2}), dst: NewVecDense(3, []float64{-1, -2, -3}), want: NewVecDense(3, []float64{1, 2, -3}), wantN: 2},
+		{
+			a:        NewVecDense(2, []float64{3, 4}),
+			want:     NewVecDense(2, []float64{0.6, 0.8}),
+			wantNorm: 5,
+		},
+		{
+			a:        NewVecDense(3, []float64{0, -2, 0}),
+			want:     NewVecDense(3, []float64{0, -1, 0}),
+			wantNorm: 2,
+		},
+		{
+			a:        NewVecDense(3, []float64{0, 0, 0}),
+			want:     NewVecDense(3, []float64{0, 0, 0}),
+			wantNorm: 0,
+		},
+		{
+			a: NewDense(2, 3, []float64{
+				3, 0, 1,
+				4, 0, 1,
+			}).ColView(0),
+			want:     NewVecDense(2, []float64{0.6, 0.8}),
+			wantNorm: 5,
+		},
 	} {
-		got := test.dst
-		var n int
-		panicked, message := panics(func() { n = got.CopyVec(test.src) })
-		if panicked {
-			t.Errorf("unexpected panic during vector copy for test %d: %s", i, message)
-		}
-		if !Equal(got, test.want) {
-			t.Errorf("test %d: unexpected result CopyVec:\ngot: %v\nwant:%v", i, got, test.want)
+		var v VecDense
+		norm := v.UnitVec(test.a)
+		if !EqualApprox(&v, test.want, 1e-15) {
+			t.Errorf("test %d: unexpected result for v = a/|a|: got: %v want: %v", i, v.RawVector(), test.want.RawVector())
 		}
-		if n != test.wantN {
-			t.Errorf("test %d: unexpected result number of elements copied: got:%d want:%d", i, n, test.wantN)
+		if math.Abs(norm-test.wantNorm) > 1e-15 {
+			t.Errorf("test %d: unexpected norm: got: %v want: %v", i, norm, test.wantNorm)
 		}
-	}
-}
 
-func TestVecDenseAddScaled(t *testing.T) {
-	t.Parallel()
-	for _, alpha := range []float64{0, 1, -1, 2.3, -2.3} {
-		method := func(receiver, a, b Matrix) {
-			type addScaledVecer interface {
-				AddScaledVec(Vector, float64, Vector)
-			}
-			v := receiver.(addScaledVecer)
-			v.AddScaledVec(a.(Vector), alpha, b.(Vector))
+		v.CopyVec(test.a)
+		norm = v.UnitVec(&v)
+		if !EqualApprox(&v, test.want, 1e-15) {
+			t.Errorf("test %d: unexpected result for v = v/|v|: got: %v want: %v", i, v.RawVector(), test.want.RawVector())
 		}
-		denseComparison := func(receiver, a, b *Dense) {
-			var sb Dense
-			sb.Scale(alpha, b)
-			receiver.Add(a, &sb)
+		if math.Abs(norm-test.wantNorm) > 1e-15 {
+			t.Errorf("test %d: unexpected in-place norm: got: %v want: %v", i, norm, test.wantNorm)
 		}
-		testTwoInput(t, "AddScaledVec", &VecDense{}, method, denseComparison, legalTypesVectorVector, legalSizeSameVec, 1e-14)
 	}
 }
 
-func TestVecDenseAdd(t *testing.T) {
+func TestVecDenseGrowVec(t *testing.T) {
 	t.Parallel()
 	for i, test := range []struct {
-		a, b Vector
-		want *VecDense
+		v    *VecDense
+		n    int
+		want []float64
 	}{
 		{
-			a:    NewVecDense(3, []float64{0, 1, 2}),
-			b:    NewVecDense(3, []float64{0, 2, 3}),
-			want: NewVecDense(3, []float64{0, 3, 5}),
+			v:    &VecDense{},
+			n:    3,
+			want: []float64{0, 0, 0},
 		},
 		{
-			a:    NewVecDense(3, []float64{0, 1, 2}),
-			b:    NewDense(3, 1, []float64{0, 2, 3}).ColView(0),
-			want: NewVecDense(3, []float64{0, 3, 5}),
+			v:    NewVecDense(3, []float64{1, 2, 3}),
+			n:    0,
+			want: []float64{1, 2, 3},
 		},
 		{
-			a:    NewDense(3, 1, []float64{0, 1, 2}).ColView(0),
-			b:    NewDense(3, 1, []float64{0, 2, 3}).ColView(0),
-			want: NewVecDense(3, []float64{0, 3, 5}),
+			v:    NewVecDense(3, []float64{1, 2, 3}),
+			n:    2,
+			want: []float64{1, 2, 3, 0, 0},
+		},
+		{
+			v:    NewVecDense(5, []float64{1, 2, 3, 4, 5}).SliceVec(0, 2).(*VecDense),
+			n:    2,
+			want: []float64{1, 2, 3, 4},
+		},
+		{
+			v: NewDense(3, 2, []float64{
+				1, 2,
+				3, 4,
+				5, 6,
+			}).ColView(1).(*VecDense).SliceVec(0, 1).(*VecDense),
+			n:    2,
+			want: []float64{2, 4, 6},
+		},
+		{
+			v: NewDense(3, 2, []float64{
+				1, 2,
+				3, 4,
+				5, 6,
+			}).ColView(0).(*VecDense),
+			n:    2,
+			want: []float64{1, 3, 5, 0, 0},
+		},
+		{
+			v:    NewVecDense(5, []float64{1, 2, 3, 4, 5}).SliceVec(0, 2).(*VecDense),
+			n:    4,
+			want: []float64{1, 2, 3, 4, 5, 0},
+		},
+		{
+			v: NewDense(3, 2, []float64{
+				1, 2,
+				3, 4,
+				5, 6,
+			}).ColView(1).(*VecDense).SliceVec(0, 1).(*VecDense),
+			n:    3,
+			want: []float64{2, 4, 6, 0},
 		},
 	} {
-		var v VecDense
-		v.AddVec(test.a.(*VecDense), test.b.(*VecDense))
-		if !reflect.DeepEqual(v.RawVector(), test.want.RawVector()) {
-			t.Errorf("unexpected result for test %d: got: %v want: %v", i, v.RawVector(), test.want.RawVector())
+		orig := test.v.Len()
+		got := test.v.GrowVec(test.n)
+		if got.Len() != len(test.want) {
+			t.Errorf("test %d: unexpected length: got: %d want: %d", i, got.Len(), len(test.want))
+			continue
+		}
+		for j, w := range test.want {
+			if got.AtVec(j) != w {
+				t.Errorf("test %d: unexpected element %d: got: %v want: %v", i, j, got.AtVec(j), w)
+			}
 		}
+		if test.v.Len() != orig {
+			t.Errorf("test %d: receiver length modified: got: %d want: %d", i, test.v.Len(), orig)
+		}
+		if len(test.want) > test.v.Cap() {
+			if inc := got.(*VecDense).RawVector().Inc; inc != 1 {
+				t.Errorf("test %d: unexpected increment after reallocation: got: %d want: 1", i, inc)
+			}
+		}
+	}
+
+	// Growing a view must not modify the data of its parent.
+	m := NewDense(3, 2, []float64{
+		1, 2,
+		3, 4,
+		5, 6,
+	})
+	want := DenseCopyOf(m)
+	m.ColView(1).(*VecDense).SliceVec(0, 1).(*VecDense).GrowVec(2)
+	if !Equal(m, want) {
+		t.Errorf("parent matrix modified by GrowVec:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+	p := NewVecDense(5, []float64{1, 2, 3, 4, 5})
+	p.SliceVec(1, 3).(*VecDense).GrowVec(2)
+	p.SliceVec(1, 3).(*VecDense).GrowVec(5).(*VecDense).SetVec(5, -1)
+	if wantP := []float64{1, 2, 3, 4, 5}; !floats.Equal(p.RawVector().Data, wantP) {
+		t.Errorf("parent vector modified by GrowVec: got: %v want: %v", p.RawVector().Data, wantP)
 	}
 }
 
-func TestVecDenseSub(t *testing.T) {
+func TestVecDenseSharesStorage(t *testing.T) {
 	t.Parallel()
-	for i, test := range []struct {
-		a, b Vector
-		want *VecDense
+	m := NewDense(3, 4, nil)
+	if m.ColView(0).(*VecDense).SharesStorage(m.ColView(1).(*VecDense)) {
+		t.Error("unexpected shared storage for distinct columns")
+	}
+	if !m.ColView(2).(*VecDense).SharesStorage(m.RowView(1).(*VecDense)) {
+		t.Error("expected shared storage for intersecting row and column")
+	}
+	if NewVecDense(3, nil).SharesStorage(NewVecDense(3, nil)) {
+		t.Error("unexpected shared storage for independent vectors")
+	}
+	if new(VecDense).SharesStorage(new(VecDense)) {
+		t.Error("unexpected shared storage for empty vectors")
+	}
+
+	// Compare with the sets of indices of the backing slice
+	// that are reachable from each view.
+	rnd := rand.New(rand.NewSource(1))
+	data := make([]float64, 40)
+	view := func() (*VecDense, map[int]bool) {
+		start := rnd.Intn(len(data))
+		inc := 1 + rnd.Intn(5)
+		n := 1 + rnd.Intn((len(data)-1-start)/inc+1)
+		idx := make(map[int]bool)
+		for i := 0; i < n; i++ {
+			idx[start+i*inc] = true
+		}
+		return &VecDense{mat: blas64.Vector{N: n, Inc: inc, Data: data[start : start+(n-1)*inc+1]}}, idx
+	}
+	for k := 0; k < 1000; k++ {
+		a, aIdx := view()
+		b, bIdx := view()
+		var want bool
+		for i := range aIdx {
+			if bIdx[i] {
+				want = true
+				break
+			}
+		}
+		if got := a.SharesStorage(b); got != want {
+			t.Errorf("unexpected result for %+v and %+v: got %t want %t", a.mat, b.mat, got, want)
+		}
+		if got := b.SharesStorage(a); got != want {
+			t.Errorf("unexpected result for %+v and %+v: got %t want %t", b.mat, a.mat, got, want)
+		}
+	}
+}
+
+func TestVecDenseDotRowCol(t *testing.T) {
+	t.Parallel()
+	src := rand.NewSource(1)
+	rnd := rand.New(src)
+	const r, c = 4, 6
+	a := NewDense(r, c, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = rnd.NormFloat64()
+	}
+	for _, inc := range []int{1, 3} {
+		for _, m := range []Matrix{a, a.T(), asBasicMatrix(a), a.Slice(1, 4, 2, 6)} {
+			mr, mc := m.Dims()
+			vr := randVecDense(mc, inc, 1, src)
+			for i := 0; i < mr; i++ {
+				var want float64
+				for j := 0; j < mc; j++ {
+					want += vr.AtVec(j) * m.At(i, j)
+				}
+				if got := vr.DotRow(m, i); math.Abs(got-want) > 1e-14 {
+					t.Errorf("unexpected DotRow for %T row %d inc=%d: got %v want %v", m, i, inc, got, want)
+				}
+			}
+			vc := randVecDense(mr, inc, 1, src)
+			for j := 0; j < mc; j++ {
+				var want float64
+				for i := 0; i < mr; i++ {
+					want += vc.AtVec(i) * m.At(i, j)
+				}
+				if got := vc.DotCol(m, j); math.Abs(got-want) > 1e-14 {
+					t.Errorf("unexpected DotCol for %T column %d inc=%d: got %v want %v", m, j, inc, got, want)
+				}
+			}
+		}
+	}
+
+	v := NewVecDense(c, nil)
+	for _, test := range []struct {
+		name string
+		fn   func()
+		want error
 	}{
-		{
-			a:    NewVecDense(3, []float64{0, 1, 2}),
-			b:    NewVecDense(3, []float64{0, 0.5, 1}),
-			want: NewVecDense(3, []float64{0, 0.5, 1}),
-		},
-		{
-			a:    NewVecDense(3, []float64{0, 1, 2}),
-			b:    NewDense(3, 1, []float64{0, 0.5, 1}).ColView(0),
-			want: NewVecDense(3, []float64{0, 0.5, 1}),
-		},
-		{
-			a:    NewDense(3, 1, []float64{0, 1, 2}).ColView(0),
-			b:    NewDense(3, 1, []float64{0, 0.5, 1}).ColView(0),
-			want: NewVecDense(3, []float64{0, 0.5, 1}),
-		},
+		{name: "DotRow index", fn: func() { v.DotRow(a, r) }, want: ErrRowAccess},
+		{name: "DotRow negative index", fn: func() { v.DotRow(a, -1) }, want: ErrRowAccess},
+		{name: "DotRow length", fn: func() { v.DotRow(a.T(), 0) }, want: ErrShape},
+		{name: "DotCol index", fn: func() { v.DotCol(a.T(), r) }, want: ErrColAccess},
+		{name: "DotCol length", fn: func() { v.DotCol(a, 0) }, want: ErrShape},
 	} {
-		var v VecDense
-		v.SubVec(test.a.(*VecDense), test.b.(*VecDense))
-		if !reflect.DeepEqual(v.RawVector(), test.want.RawVector()) {
-			t.Errorf("unexpected result for test %d: got: %v want: %v", i, v.RawVector(), test.want.RawVector())
+		panicked, message := panics(test.fn)
+		if !panicked || message != test.want.Error() {
+			t.Errorf("%s: unexpected panic: got %q want %q", test.name, message, test.want)
 		}
 	}
 }
 
-func TestVecDenseMulElem(t *testing.T) {
+func TestVecDenseRawData(t *testing.T) {
 	t.Parallel()
-	for i, test := range []struct {
-		a, b Vector
-		want *VecDense
+	for i, v := range []*VecDense{
+		NewVecDense(3, []float64{1, 2, 3}),
+		NewDense(3, 2, []float64{1, 0, 2, 0, 3, 0}).ColView(0).(*VecDense),
+	} {
+		got := v.RawData()
+		want := []float64{1, 2, 3}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("test %d: unexpected raw data: got: %v want: %v", i, got, want)
+		}
+		got[0] = 10
+		if v.AtVec(0) != 1 {
+			t.Errorf("test %d: raw data aliases receiver", i)
+		}
+
+		var u VecDense
+		u.SetRawData(got)
+		if u.Len() != 3 || u.AtVec(0) != 10 {
+			t.Errorf("test %d: unexpected adopted vector: %v", i, u.RawVector())
+		}
+		got[1] = 20
+		if u.AtVec(1) != 20 {
+			t.Errorf("test %d: adopted vector does not share data", i)
+		}
+
+		v.SetRawData([]float64{4, 5, 6})
+		if want := NewVecDense(3, []float64{4, 5, 6}); !Equal(v, want) {
+			t.Errorf("test %d: unexpected copied vector: got: %v want: %v", i, v.RawData(), want.RawData())
+		}
+	}
+
+	if panicked, _ := panics(func() { NewVecDense(2, nil).SetRawData([]float64{1}) }); !panicked {
+		t.Error("expected panic for length mismatch")
+	}
+	if panicked, _ := panics(func() { new(VecDense).SetRawData(nil) }); !panicked {
+		t.Error("expected panic for empty data")
+	}
+}
+
+func TestVecDensePermute(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		v       *VecDense
+		p       []int
+		inverse bool
+		want    []float64
 	}{
 		{
-			a:    NewVecDense(3, []float64{0, 1, 2}),
-			b:    NewVecDense(3, []float64{0, 2, 3}),
-			want: NewVecDense(3, []float64{0, 2, 6}),
+			v:    NewVecDense(4, []float64{10, 11, 12, 13}),
+			p:    []int{2, 0, 3, 1},
+			want: []float64{12, 10, 13, 11},
 		},
 		{
-			a:    NewVecDense(3, []float64{0, 1, 2}),
-			b:    NewDense(3, 1, []float64{0, 2, 3}).ColView(0),
-			want: NewVecDense(3, []float64{0, 2, 6}),
+			v:       NewVecDense(4, []float64{10, 11, 12, 13}),
+			p:       []int{2, 0, 3, 1},
+			inverse: true,
+			want:    []float64{11, 13, 10, 12},
 		},
 		{
-			a:    NewDense(3, 1, []float64{0, 1, 2}).ColView(0),
-			b:    NewDense(3, 1, []float64{0, 2, 3}).ColView(0),
-			want: NewVecDense(3, []float64{0, 2, 6}),
+			v: &VecDense{mat: blas64.Vector{N: 3, Inc: 2, Data: []float64{
+				1, -1, 2, -1, 3,
+			}}},
+			p:    []int{1, 2, 0},
+			want: []float64{2, 3, 1},
+		},
+	} {
+		d := make([]float64, test.v.Len())
+		for i := range d {
+			d[i] = test.v.AtVec(i)
+		}
+		var want VecDense
+		var perm Dense
+		perm.Permutation(len(test.p), test.p)
+		if test.inverse {
+			want.MulVec(perm.T(), NewVecDense(len(d), d))
+		} else {
+			want.MulVec(&perm, NewVecDense(len(d), d))
+		}
+		if !Equal(&want, NewVecDense(len(test.want), test.want)) {
+			t.Fatalf("bad test: permutation matrix product does not match want")
+		}
+
+		test.v.Permute(test.p, test.inverse)
+		if !Equal(test.v, NewVecDense(len(test.want), test.want)) {
+			t.Errorf("unexpected result for p=%v inverse=%t: got %v want %v",
+				test.p, test.inverse, Formatted(test.v.T()), test.want)
+		}
+		for i := 1; i < len(test.v.mat.Data); i += test.v.mat.Inc {
+			if test.v.mat.Inc > 1 && test.v.mat.Data[i] != -1 {
+				t.Errorf("unexpected modification of non-vector element")
+			}
+		}
+	}
+
+	for _, p := range [][]int{
+		{0, 1, 1},
+		{0, 1, 3},
+		{-1, 0, 1},
+	} {
+		v := NewVecDense(3, nil)
+		panicked, message := panics(func() { v.Permute(p, false) })
+		if !panicked || message != badPermutation {
+			t.Errorf("expected panic for invalid permutation %v, got %q", p, message)
+		}
+	}
+	v := NewVecDense(3, nil)
+	panicked, message := panics(func() { v.Permute([]int{0, 1}, false) })
+	if !panicked || message != badSliceLength {
+		t.Errorf("expected panic for short permutation, got %q", message)
+	}
+}
+
+func TestVecDenseThresholdVec(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		a     Vector
+		tol   float64
+		want  []float64
+		wantN int
+	}{
+		{
+			a:     NewVecDense(5, []float64{0.5, -2, 0, -0.1, 1}),
+			tol:   0.5,
+			want:  []float64{0, -2, 0, 0, 1},
+			wantN: 2,
+		},
+		{
+			a: &VecDense{mat: blas64.Vector{N: 3, Inc: 2, Data: []float64{
+				1e-9, 7, -3, 7, 2,
+			}}},
+			tol:   2,
+			want:  []float64{0, -3, 0},
+			wantN: 2,
+		},
+		{
+			a:     &basicVector{m: []float64{-1, 0, 1}},
+			tol:   1,
+			want:  []float64{0, 0, 0},
+			wantN: 2,
+		},
+	} {
+		var v VecDense
+		n := v.ThresholdVec(test.a, test.tol)
+		if n != test.wantN {
+			t.Errorf("unexpected count: got %d want %d", n, test.wantN)
+		}
+		if !Equal(&v, NewVecDense(len(test.want), test.want)) {
+			t.Errorf("unexpected result: got %v want %v", Formatted(v.T()), test.want)
+		}
+
+		// In-place operation.
+		if a, ok := test.a.(*VecDense); ok {
+			n = a.ThresholdVec(a, test.tol)
+			if n != test.wantN {
+				t.Errorf("unexpected in-place count: got %d want %d", n, test.wantN)
+			}
+			if !Equal(a, NewVecDense(len(test.want), test.want)) {
+				t.Errorf("unexpected in-place result: got %v want %v", Formatted(a.T()), test.want)
+			}
+		}
+	}
+}
+
+func TestVecDenseSoftThresholdVec(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		a      Vector
+		lambda float64
+		want   []float64
+	}{
+		{
+			a:      NewVecDense(5, []float64{0.5, -2, 0, -0.1, 1}),
+			lambda: 0.5,
+			want:   []float64{0, -1.5, 0, 0, 0.5},
+		},
+		{
+			a: &VecDense{mat: blas64.Vector{N: 3, Inc: 2, Data: []float64{
+				1e-9, 7, -3, 7, 2,
+			}}},
+			lambda: 1,
+			want:   []float64{0, -2, 1},
+		},
+		{
+			a:      &basicVector{m: []float64{-1, 0, 4}},
+			lambda: 0,
+			want:   []float64{-1, 0, 4},
+		},
+	} {
+		var v VecDense
+		v.SoftThresholdVec(test.a, test.lambda)
+		if !Equal(&v, NewVecDense(len(test.want), test.want)) {
+			t.Errorf("unexpected result: got %v want %v", Formatted(v.T()), test.want)
+		}
+
+		// In-place operation must not touch elements between strides.
+		if a, ok := test.a.(*VecDense); ok {
+			a.SoftThresholdVec(a, test.lambda)
+			if !Equal(a, NewVecDense(len(test.want), test.want)) {
+				t.Errorf("unexpected in-place result: got %v want %v", Formatted(a.T()), test.want)
+			}
+			for i := 1; a.mat.Inc > 1 && i < len(a.mat.Data); i += a.mat.Inc {
+				if a.mat.Data[i] != 7 {
+					t.Errorf("unexpected modification of non-vector element %d", i)
+				}
+			}
+		}
+	}
+
+	// NaN elements are propagated rather than thresholded to zero.
+	var v VecDense
+	v.SoftThresholdVec(NewVecDense(3, []float64{math.NaN(), 5, 0.5}), 1)
+	if want := NewVecDense(3, []float64{math.NaN(), 4, 0}); !sameNaNs(&v, want) {
+		t.Errorf("unexpected result with NaN: got %v want %v", Formatted(v.T()), Formatted(want.T()))
+	}
+}
+
+func TestVecDenseExpLogVec(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		a       []float64
+		wantExp []float64
+		wantLog []float64
+	}{
+		{
+			a:       []float64{0, 1, -1, 2},
+			wantExp: []float64{1, math.E, 1 / math.E, math.Exp(2)},
+			wantLog: []float64{math.Inf(-1), 0, math.NaN(), math.Ln2},
+		},
+		{
+			a:       []float64{math.Inf(-1), math.Inf(1), math.NaN()},
+			wantExp: []float64{0, math.Inf(1), math.NaN()},
+			wantLog: []float64{math.NaN(), math.Inf(1), math.NaN()},
+		},
+	} {
+		wantExp := NewVecDense(len(test.wantExp), test.wantExp)
+		wantLog := NewVecDense(len(test.wantLog), test.wantLog)
+		for _, a := range []Vector{
+			NewVecDense(len(test.a), test.a),
+			&basicVector{m: test.a},
+			makeVecDenseInc(2, test.a),
+		} {
+			var gotExp, gotLog VecDense
+			gotExp.ExpVec(a)
+			gotLog.LogVec(a)
+			if !sameNaNs(&gotExp, wantExp) {
+				t.Errorf("unexpected ExpVec result for %T: got %v want %v", a, Formatted(gotExp.T()), test.wantExp)
+			}
+			if !sameNaNs(&gotLog, wantLog) {
+				t.Errorf("unexpected LogVec result for %T: got %v want %v", a, Formatted(gotLog.T()), test.wantLog)
+			}
+		}
+
+		// In-place round trip.
+		v := makeVecDenseInc(3, test.a)
+		v.ExpVec(v)
+		if !sameNaNs(v, wantExp) {
+			t.Errorf("unexpected in-place ExpVec result: got %v want %v", Formatted(v.T()), test.wantExp)
+		}
+		v.LogVec(v)
+		for i, x := range test.a {
+			if got := v.AtVec(i); got != x && !(math.IsNaN(got) && math.IsNaN(x)) && math.Abs(got-x) > 1e-15 {
+				t.Errorf("unexpected in-place round trip at %d: got %v want %v", i, got, x)
+			}
+		}
+	}
+}
+
+func TestVecDenseReciprocalVec(t *testing.T) {
+	t.Parallel()
+	a := []float64{2, -0.5, 0, math.Copysign(0, -1), math.Inf(1), math.NaN()}
+	want := NewVecDense(len(a), []float64{0.5, -2, math.Inf(1), math.Inf(-1), 0, math.NaN()})
+	for _, av := range []Vector{
+		NewVecDense(len(a), a),
+		&basicVector{m: a},
+		makeVecDenseInc(2, a),
+	} {
+		var got VecDense
+		got.ReciprocalVec(av)
+		if !sameNaNs(&got, want) {
+			t.Errorf("unexpected result for %T: got %v want %v", av, Formatted(got.T()), Formatted(want.T()))
+		}
+	}
+
+	v := makeVecDenseInc(3, a)
+	v.ReciprocalVec(v)
+	if !sameNaNs(v, want) {
+		t.Errorf("unexpected in-place result: got %v want %v", Formatted(v.T()), Formatted(want.T()))
+	}
+}
+
+func TestVecDenseTanhSigmoidVec(t *testing.T) {
+	t.Parallel()
+	a := []float64{0, 1, -1, 800, -800, math.Inf(1), math.Inf(-1), math.NaN()}
+	wantTanh := NewVecDense(len(a), []float64{0, math.Tanh(1), -math.Tanh(1), 1, -1, 1, -1, math.NaN()})
+	wantSigmoid := NewVecDense(len(a), []float64{0.5, 1 / (1 + math.Exp(-1)), 1 / (1 + math.E), 1, 0, 1, 0, math.NaN()})
+	for _, av := range []Vector{
+		NewVecDense(len(a), a),
+		&basicVector{m: a},
+		makeVecDenseInc(2, a),
+	} {
+		var gotTanh, gotSigmoid VecDense
+		gotTanh.TanhVec(av)
+		gotSigmoid.SigmoidVec(av)
+		if !sameNaNs(&gotTanh, wantTanh) {
+			t.Errorf("unexpected TanhVec result for %T: got %v want %v", av, Formatted(gotTanh.T()), Formatted(wantTanh.T()))
+		}
+		if !sameNaNs(&gotSigmoid, wantSigmoid) {
+			t.Errorf("unexpected SigmoidVec result for %T: got %v want %v", av, Formatted(gotSigmoid.T()), Formatted(wantSigmoid.T()))
+		}
+	}
+
+	v := makeVecDenseInc(3, a)
+	v.SigmoidVec(v)
+	if !sameNaNs(v, wantSigmoid) {
+		t.Errorf("unexpected in-place SigmoidVec result: got %v want %v", Formatted(v.T()), Formatted(wantSigmoid.T()))
+	}
+
+	// The sigmoid of large negative inputs must keep its relative accuracy.
+	for _, x := range []float64{-30, -300, -700} {
+		var s VecDense
+		s.SigmoidVec(NewVecDense(1, []float64{x}))
+		if got, want := s.AtVec(0), math.Exp(x); math.Abs(got-want) > 1e-12*want {
+			t.Errorf("unexpected sigmoid of %v: got %v want %v", x, got, want)
+		}
+	}
+}
+
+func TestVecDenseFlatten(t *testing.T) {
+	t.Parallel()
+	base := NewDense(3, 4, []float64{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	})
+	for _, test := range []struct {
+		a    Matrix
+		want []float64
+	}{
+		{a: base, want: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+		{a: base.Slice(1, 3, 1, 3), want: []float64{6, 7, 10, 11}},
+		{a: base.Slice(0, 2, 2, 3).T(), want: []float64{3, 7}},
+		{a: asBasicMatrix(base.Slice(0, 2, 0, 2).(*Dense)), want: []float64{1, 2, 5, 6}},
+	} {
+		var v VecDense
+		v.Flatten(test.a)
+		if !Equal(&v, NewVecDense(len(test.want), test.want)) {
+			t.Errorf("unexpected result: got %v want %v", Formatted(v.T()), test.want)
+		}
+
+		// Strided receiver.
+		n := len(test.want)
+		w := &VecDense{mat: blas64.Vector{N: n, Inc: 2, Data: make([]float64, 2*n-1)}}
+		w.Flatten(test.a)
+		if !Equal(w, NewVecDense(n, test.want)) {
+			t.Errorf("unexpected strided result: got %v want %v", Formatted(w.T()), test.want)
+		}
+	}
+
+	// The Frobenius norm is the 2-norm of the flattened matrix.
+	var v VecDense
+	v.Flatten(base)
+	if got, want := Norm(&v, 2), Norm(base, 2); math.Abs(got-want) > 1e-14 {
+		t.Errorf("unexpected norm of flattened matrix: got %v want %v", got, want)
+	}
+
+	w := NewVecDense(5, nil)
+	panicked, message := panics(func() { w.Flatten(base.Slice(0, 2, 0, 2)) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched receiver, got %q", message)
+	}
+}
+
+func TestVecDenseComparisonMasks(t *testing.T) {
+	t.Parallel()
+	a := []float64{-1, 2, 0.5, math.NaN(), 3}
+	b := []float64{0, 2, 1, 1, -3}
+	const c = 0.5
+	for _, test := range []struct {
+		name string
+		fn   func(v *VecDense, a, b Vector)
+		want []float64
+	}{
+		{
+			name: "GreaterThanVec",
+			fn:   func(v *VecDense, a, _ Vector) { v.GreaterThanVec(a, c) },
+			want: []float64{0, 1, 0, 0, 1},
+		},
+		{
+			name: "LessThanVec",
+			fn:   func(v *VecDense, a, _ Vector) { v.LessThanVec(a, c) },
+			want: []float64{1, 0, 0, 0, 0},
+		},
+		{
+			name: "GreaterThanElemVec",
+			fn:   func(v *VecDense, a, b Vector) { v.GreaterThanElemVec(a, b) },
+			want: []float64{0, 0, 0, 0, 1},
+		},
+		{
+			name: "LessThanElemVec",
+			fn:   func(v *VecDense, a, b Vector) { v.LessThanElemVec(a, b) },
+			want: []float64{1, 0, 1, 0, 0},
+		},
+	} {
+		want := NewVecDense(len(test.want), test.want)
+
+		var v VecDense
+		test.fn(&v, NewVecDense(len(a), a), &basicVector{m: b})
+		if !Equal(&v, want) {
+			t.Errorf("%s: unexpected result: got %v want %v", test.name, Formatted(v.T()), test.want)
+		}
+
+		// Strided operands and receiver.
+		sa := &VecDense{mat: blas64.Vector{N: len(a), Inc: 2, Data: make([]float64, 2*len(a)-1)}}
+		sa.CopyVec(NewVecDense(len(a), a))
+		sb := &VecDense{mat: blas64.Vector{N: len(b), Inc: 3, Data: make([]float64, 3*len(b)-2)}}
+		sb.CopyVec(NewVecDense(len(b), b))
+		sv := &VecDense{mat: blas64.Vector{N: len(a), Inc: 2, Data: make([]float64, 2*len(a)-1)}}
+		test.fn(sv, sa, sb)
+		if !Equal(sv, want) {
+			t.Errorf("%s: unexpected strided result: got %v want %v", test.name, Formatted(sv.T()), test.want)
+		}
+
+		// In-place operation.
+		test.fn(sa, sa, sb)
+		if !Equal(sa, want) {
+			t.Errorf("%s: unexpected in-place result: got %v want %v", test.name, Formatted(sa.T()), test.want)
+		}
+	}
+
+	panicked, message := panics(func() {
+		var v VecDense
+		v.LessThanElemVec(NewVecDense(2, nil), NewVecDense(3, nil))
+	})
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched lengths, got %q", message)
+	}
+}
+
+func TestVecDenseWhereVec(t *testing.T) {
+	t.Parallel()
+	mask := []float64{1, 0, -2, 0, math.NaN()}
+	a := []float64{1, 2, 3, 4, 5}
+	b := []float64{-1, -2, -3, -4, -5}
+	want := NewVecDense(5, []float64{1, -2, 3, -4, 5})
+
+	strided := func(d []float64, inc int) *VecDense {
+		v := &VecDense{mat: blas64.Vector{N: len(d), Inc: inc, Data: make([]float64, inc*(len(d)-1)+1)}}
+		v.CopyVec(NewVecDense(len(d), d))
+		return v
+	}
+
+	var v VecDense
+	v.WhereVec(NewVecDense(5, mask), &basicVector{m: a}, NewVecDense(5, b))
+	if !Equal(&v, want) {
+		t.Errorf("unexpected result: got %v want %v", Formatted(v.T()), Formatted(want.T()))
+	}
+
+	sv := strided(make([]float64, 5), 3)
+	sv.WhereVec(strided(mask, 2), strided(a, 3), strided(b, 4))
+	if !Equal(sv, want) {
+		t.Errorf("unexpected strided result: got %v want %v", Formatted(sv.T()), Formatted(want.T()))
+	}
+
+	for i := 0; i < 3; i++ {
+		vecs := []*VecDense{strided(mask, 2), strided(a, 1), strided(b, 2)}
+		dst := vecs[i]
+		dst.WhereVec(vecs[0], vecs[1], vecs[2])
+		if !Equal(dst, want) {
+			t.Errorf("unexpected result aliasing input %d: got %v want %v", i, Formatted(dst.T()), Formatted(want.T()))
+		}
+	}
+
+	panicked, message := panics(func() {
+		var v VecDense
+		v.WhereVec(NewVecDense(2, nil), NewVecDense(2, nil), NewVecDense(3, nil))
+	})
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched lengths, got %q", message)
+	}
+}
+
+func TestVecDenseCopyWhereVec(t *testing.T) {
+	t.Parallel()
+	mask := []float64{1, 0, -2, 0, math.NaN()}
+	a := []float64{1, 2, 3, 4, 5}
+	v := []float64{-1, -2, -3, -4, -5}
+	want := NewVecDense(5, []float64{1, -2, 3, -4, 5})
+
+	dst := NewVecDense(5, append([]float64(nil), v...))
+	dst.CopyWhereVec(&basicVector{m: a}, NewVecDense(5, mask))
+	if !Equal(dst, want) {
+		t.Errorf("unexpected result: got %v want %v", Formatted(dst.T()), Formatted(want.T()))
+	}
+
+	sv := makeVecDenseInc(3, v)
+	sv.CopyWhereVec(makeVecDenseInc(2, a), makeVecDenseInc(4, mask))
+	if !Equal(sv, want) {
+		t.Errorf("unexpected strided result: got %v want %v", Formatted(sv.T()), Formatted(want.T()))
+	}
+
+	// The receiver may be the mask.
+	m := NewVecDense(3, []float64{0, 2, 0})
+	m.CopyWhereVec(NewVecDense(3, []float64{5, 6, 7}), m)
+	if !Equal(m, NewVecDense(3, []float64{0, 6, 0})) {
+		t.Errorf("unexpected result with receiver mask: got %v", Formatted(m.T()))
+	}
+
+	for _, test := range []struct {
+		name string
+		v    *VecDense
+	}{
+		{name: "mismatched receiver", v: NewVecDense(3, nil)},
+		{name: "empty receiver", v: &VecDense{}},
+	} {
+		panicked, message := panics(func() { test.v.CopyWhereVec(NewVecDense(2, nil), NewVecDense(2, nil)) })
+		if !panicked || message != ErrShape.Error() {
+			t.Errorf("%s: expected ErrShape, got %q", test.name, message)
+		}
+	}
+}
+
+func TestVecDenseAsDense(t *testing.T) {
+	t.Parallel()
+	for _, inc := range []int{1, 3} {
+		v := &VecDense{mat: blas64.Vector{N: 3, Inc: inc, Data: make([]float64, 3*inc)}}
+		for i := 0; i < 3; i++ {
+			v.SetVec(i, float64(i+1))
+		}
+
+		col := v.AsDense()
+		if r, c := col.Dims(); r != 3 || c != 1 {
+			t.Errorf("unexpected column dimensions for inc=%d: %d×%d", inc, r, c)
+		}
+		if !Equal(col, NewDense(3, 1, []float64{1, 2, 3})) {
+			t.Errorf("unexpected column for inc=%d:\n%v", inc, Formatted(col))
+		}
+		col.Set(1, 0, -2)
+		if v.AtVec(1) != -2 {
+			t.Errorf("column write not reflected in vector for inc=%d", inc)
+		}
+		v.SetVec(1, 2)
+
+		row := v.AsDenseRow()
+		if r, c := row.Dims(); r != 1 || c != 3 {
+			t.Errorf("unexpected row dimensions for inc=%d: %d×%d", inc, r, c)
+		}
+		if !Equal(row, NewDense(1, 3, []float64{1, 2, 3})) {
+			t.Errorf("unexpected row for inc=%d:\n%v", inc, Formatted(row))
+		}
+		row.Set(0, 2, -3)
+		shared := v.AtVec(2) == -3
+		if shared != (inc == 1) {
+			t.Errorf("unexpected row sharing for inc=%d: shared=%t", inc, shared)
+		}
+
+		// The column view must be usable where a RawMatrixer is needed.
+		var p Dense
+		p.Mul(v.AsDenseRow(), v.AsDense())
+		if got, want := p.At(0, 0), Dot(v, v); got != want {
+			t.Errorf("unexpected product for inc=%d: got %v want %v", inc, got, want)
+		}
+	}
+
+	panicked, message := panics(func() { (&VecDense{}).AsDense() })
+	if !panicked || message != ErrZeroLength.Error() {
+		t.Errorf("expected ErrZeroLength for empty vector, got %q", message)
+	}
+}
+
+func TestVecDenseWeightedSumVec(t *testing.T) {
+	t.Parallel()
+	a := NewVecDense(3, []float64{1, 2, 3})
+	b := &VecDense{mat: blas64.Vector{N: 3, Inc: 2, Data: []float64{4, 0, 5, 0, 6}}}
+	c := &basicVector{m: []float64{-1, 0, 1}}
+
+	var v VecDense
+	v.WeightedSumVec([]float64{2, 0.5, 3}, []Vector{a, b, c})
+	want := NewVecDense(3, []float64{1, 6.5, 12})
+	if !Equal(&v, want) {
+		t.Errorf("unexpected weighted sum: got %v want %v", Formatted(v.T()), Formatted(want.T()))
+	}
+
+	// The receiver may be one of the inputs.
+	a.WeightedSumVec([]float64{2, 0.5, 3}, []Vector{a, b, c})
+	if !Equal(a, want) {
+		t.Errorf("unexpected aliased weighted sum: got %v want %v", Formatted(a.T()), Formatted(want.T()))
+	}
+
+	v.WeightedSumVec(nil, nil)
+	if !v.IsEmpty() {
+		t.Errorf("expected empty receiver for empty input")
+	}
+
+	panicked, message := panics(func() {
+		var v VecDense
+		v.WeightedSumVec([]float64{1}, []Vector{a, b})
+	})
+	if !panicked || message != ErrSliceLengthMismatch.Error() {
+		t.Errorf("expected ErrSliceLengthMismatch, got %q", message)
+	}
+	panicked, message = panics(func() {
+		var v VecDense
+		v.WeightedSumVec([]float64{1, 1}, []Vector{a, NewVecDense(2, nil)})
+	})
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched vectors, got %q", message)
+	}
+}
+
+func TestVecDenseLerpVec(t *testing.T) {
+	t.Parallel()
+	a := []float64{1, -2, 0.1, 3}
+	b := []float64{5, 2, 0.7, 3}
+	for _, test := range []struct {
+		t    float64
+		want []float64
+	}{
+		{t: 0, want: a},
+		{t: 1, want: b},
+		{t: 0.5, want: []float64{3, 0, 0.4, 3}},
+		{t: 2, want: []float64{9, 6, 1.3, 3}},
+		{t: -1, want: []float64{-3, -6, -0.5, 3}},
+	} {
+		want := NewVecDense(len(test.want), test.want)
+		tol := 1e-15
+		if test.t == 0 || test.t == 1 {
+			// The endpoints must be reproduced exactly.
+			tol = 0
+		}
+
+		var v VecDense
+		v.LerpVec(NewVecDense(len(a), a), NewVecDense(len(b), b), test.t)
+		if !EqualApprox(&v, want, tol) {
+			t.Errorf("unexpected result for t=%v: got %v want %v", test.t, Formatted(v.T()), test.want)
+		}
+
+		v.Reset()
+		v.LerpVec(&basicVector{m: a}, NewVecDense(len(b), b), test.t)
+		if !EqualApprox(&v, want, tol) {
+			t.Errorf("unexpected result for non-VecDense input with t=%v: got %v want %v", test.t, Formatted(v.T()), test.want)
+		}
+
+		sa := &VecDense{mat: blas64.Vector{N: len(a), Inc: 2, Data: make([]float64, 2*len(a)-1)}}
+		sa.CopyVec(NewVecDense(len(a), a))
+		sb := &VecDense{mat: blas64.Vector{N: len(b), Inc: 3, Data: make([]float64, 3*len(b)-2)}}
+		sb.CopyVec(NewVecDense(len(b), b))
+		sb.LerpVec(sa, sb, test.t)
+		if !EqualApprox(sb, want, tol) {
+			t.Errorf("unexpected in-place strided result for t=%v: got %v want %v", test.t, Formatted(sb.T()), test.want)
+		}
+	}
+
+	panicked, message := panics(func() {
+		var v VecDense
+		v.LerpVec(NewVecDense(2, nil), NewVecDense(3, nil), 0.5)
+	})
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched lengths, got %q", message)
+	}
+
+	// The endpoints are exact even for non-finite elements.
+	inf, nan := math.Inf(1), math.NaN()
+	x := NewVecDense(3, []float64{1, -inf, 2})
+	y := NewVecDense(3, []float64{inf, 3, nan})
+	for _, test := range []struct {
+		t    float64
+		want *VecDense
+	}{
+		{t: 0, want: x},
+		{t: 1, want: y},
+	} {
+		var v VecDense
+		v.LerpVec(x, y, test.t)
+		if !sameNaNs(&v, test.want) {
+			t.Errorf("unexpected non-finite result for t=%v: got %v want %v", test.t, Formatted(v.T()), Formatted(test.want.T()))
+		}
+		v.Reset()
+		v.LerpVec(&basicVector{m: x.RawVector().Data}, &basicVector{m: y.RawVector().Data}, test.t)
+		if !sameNaNs(&v, test.want) {
+			t.Errorf("unexpected non-finite result for non-VecDense input with t=%v: got %v want %v", test.t, Formatted(v.T()), Formatted(test.want.T()))
+		}
+	}
+}
+
+func TestVecDenseGeometricHarmonicMean(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		v         *VecDense
+		geometric float64
+		harmonic  float64
+	}{
+		{
+			v:         NewVecDense(1, []float64{5}),
+			geometric: 5,
+			harmonic:  5,
+		},
+		{
+			v:         NewVecDense(3, []float64{1, 2, 4}),
+			geometric: 2,
+			harmonic:  12.0 / 7,
+		},
+		{
+			v: &VecDense{mat: blas64.Vector{N: 2, Inc: 3, Data: []float64{
+				2, -1, -1, 8,
+			}}},
+			geometric: 4,
+			harmonic:  3.2,
+		},
+		{
+			v:         NewVecDense(3, []float64{1e300, 1e300, 1e300}),
+			geometric: 1e300,
+			harmonic:  1e300,
+		},
+		{
+			v:         NewVecDense(3, []float64{1, 0, 4}),
+			geometric: 0,
+			harmonic:  0,
+		},
+		{
+			v:         NewVecDense(3, []float64{1, -2, 4}),
+			geometric: math.NaN(),
+			harmonic:  math.NaN(),
+		},
+	} {
+		if got := test.v.GeometricMean(); !floats.EqualWithinAbsOrRel(got, test.geometric, 1e-12, 1e-12) &&
+			!(math.IsNaN(got) && math.IsNaN(test.geometric)) {
+			t.Errorf("unexpected geometric mean of %v: got %v want %v", Formatted(test.v.T()), got, test.geometric)
+		}
+		if got := test.v.HarmonicMean(); !floats.EqualWithinAbsOrRel(got, test.harmonic, 1e-14, 1e-14) &&
+			!(math.IsNaN(got) && math.IsNaN(test.harmonic)) {
+			t.Errorf("unexpected harmonic mean of %v: got %v want %v", Formatted(test.v.T()), got, test.harmonic)
+		}
+	}
+
+	for _, fn := range []func(*VecDense) float64{
+		(*VecDense).GeometricMean,
+		(*VecDense).HarmonicMean,
+	} {
+		panicked, message := panics(func() { fn(&VecDense{}) })
+		if !panicked || message != ErrZeroLength.Error() {
+			t.Errorf("expected ErrZeroLength for empty vector, got %q", message)
+		}
+	}
+}
+
+func TestCopyVec(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		src   *VecDense
+		dst   *VecDense
+		want  *VecDense
+		wantN int
+	}{
+		{src: NewVecDense(1, nil), dst: NewVecDense(1, nil), want: NewVecDense(1, nil), wantN: 1},
+		{src: NewVecDense(3, []float64{1, 2, 3}), dst: NewVecDense(2, []float64{-1, -2}), want: NewVecDense(2, []float64{1, 2}), wantN: 2},
+		{src: NewVecDense(2, []float64{1, 2}), dst: NewVecDense(3, []float64{-1, -2, -3}), want: NewVecDense(3, []float64{1, 2, -3}), wantN: 2},
+	} {
+		got := test.dst
+		var n int
+		panicked, message := panics(func() { n = got.CopyVec(test.src) })
+		if panicked {
+			t.Errorf("unexpected panic during vector copy for test %d: %s", i, message)
+		}
+		if !Equal(got, test.want) {
+			t.Errorf("test %d: unexpected result CopyVec:\ngot: %v\nwant:%v", i, got, test.want)
+		}
+		if n != test.wantN {
+			t.Errorf("test %d: unexpected result number of elements copied: got:%d want:%d", i, n, test.wantN)
+		}
+	}
+}
+
+func TestVecDenseSetVecSlice(t *testing.T) {
+	t.Parallel()
+	for k, test := range []struct {
+		dst  *VecDense
+		i    int
+		a    Vector
+		want []float64
+	}{
+		{
+			dst:  NewVecDense(4, nil),
+			i:    0,
+			a:    NewVecDense(4, []float64{1, 2, 3, 4}),
+			want: []float64{1, 2, 3, 4},
+		},
+		{
+			dst:  NewVecDense(5, nil),
+			i:    2,
+			a:    NewVecDense(2, []float64{1, 2}),
+			want: []float64{0, 0, 1, 2, 0},
+		},
+		{
+			dst:  NewDense(4, 2, nil).ColView(1).(*VecDense),
+			i:    1,
+			a:    NewDense(3, 3, []float64{1, 0, 0, 2, 0, 0, 3, 0, 0}).ColView(0),
+			want: []float64{0, 1, 2, 3},
+		},
+		{
+			dst:  NewVecDense(3, []float64{9, 9, 9}),
+			i:    1,
+			a:    &basicVector{m: []float64{-1}},
+			want: []float64{9, -1, 9},
+		},
+	} {
+		test.dst.SetVecSlice(test.i, test.a)
+		want := NewVecDense(len(test.want), test.want)
+		if !Equal(test.dst, want) {
+			t.Errorf("test %d: unexpected result: got: %v want: %v", k, Formatted(test.dst.T()), test.want)
+		}
+	}
+
+	// Overlapping sources must behave as if copied before writing.
+	for k, test := range []struct {
+		i, lo, hi int
+		want      []float64
+	}{
+		{i: 1, lo: 0, hi: 4, want: []float64{1, 1, 2, 3, 4}},
+		{i: 0, lo: 1, hi: 5, want: []float64{2, 3, 4, 5, 5}},
+		{i: 2, lo: 0, hi: 3, want: []float64{1, 2, 1, 2, 3}},
+	} {
+		for _, inc := range []int{1, 2} {
+			dst := makeVecDenseInc(inc, []float64{1, 2, 3, 4, 5})
+			dst.SetVecSlice(test.i, dst.SliceVec(test.lo, test.hi))
+			want := NewVecDense(len(test.want), test.want)
+			if !Equal(dst, want) {
+				t.Errorf("overlap test %d inc=%d: unexpected result: got: %v want: %v", k, inc, Formatted(dst.T()), test.want)
+			}
+		}
+	}
+
+	v := NewVecDense(3, nil)
+	a := NewVecDense(2, nil)
+	for _, i := range []int{-1, 2, 3} {
+		if panicked, _ := panics(func() { v.SetVecSlice(i, a) }); !panicked {
+			t.Errorf("expected panic for offset %d", i)
+		}
+	}
+}
+
+func TestVecDenseGatherScatterVec(t *testing.T) {
+	t.Parallel()
+	for k, test := range []struct {
+		a    Vector
+		idx  []int
+		want []float64
+	}{
+		{
+			a:    NewVecDense(4, []float64{1, 2, 3, 4}),
+			idx:  []int{3, 0, 1},
+			want: []float64{4, 1, 2},
+		},
+		{
+			a:    NewVecDense(3, []float64{1, 2, 3}),
+			idx:  []int{2, 2, 2, 0},
+			want: []float64{3, 3, 3, 1},
+		},
+		{
+			a:    NewDense(3, 2, []float64{1, 0, 2, 0, 3, 0}).ColView(0),
+			idx:  []int{1, 2},
+			want: []float64{2, 3},
+		},
+		{
+			a:    &basicVector{m: []float64{5, 6, 7}},
+			idx:  []int{2, 1},
+			want: []float64{7, 6},
+		},
+	} {
+		var v VecDense
+		v.GatherVec(test.a, test.idx)
+		want := NewVecDense(len(test.want), test.want)
+		if !Equal(&v, want) {
+			t.Errorf("test %d: unexpected gather result: got: %v want: %v", k, v.RawVector().Data, test.want)
+		}
+	}
+
+	dst := NewVecDense(5, []float64{9, 9, 9, 9, 9})
+	dst.ScatterVec([]int{4, 0, 2}, NewVecDense(3, []float64{1, 2, 3}))
+	want := NewVecDense(5, []float64{2, 9, 3, 9, 1})
+	if !Equal(dst, want) {
+		t.Errorf("unexpected scatter result: got: %v want: %v", dst.RawVector().Data, want.RawVector().Data)
+	}
+
+	dst = NewVecDense(3, []float64{1, 2, 3})
+	dst.ScatterVec([]int{2, 0, 1}, dst)
+	want = NewVecDense(3, []float64{2, 3, 1})
+	if !Equal(dst, want) {
+		t.Errorf("unexpected in-place scatter result: got: %v want: %v", dst.RawVector().Data, want.RawVector().Data)
+	}
+
+	a := NewVecDense(3, nil)
+	for _, idx := range [][]int{{-1}, {3}, {0, 1, 5}} {
+		if panicked, _ := panics(func() { new(VecDense).GatherVec(a, idx) }); !panicked {
+			t.Errorf("expected gather panic for indices %v", idx)
+		}
+		if panicked, _ := panics(func() { a.ScatterVec(idx, NewVecDense(len(idx), nil)) }); !panicked {
+			t.Errorf("expected scatter panic for indices %v", idx)
+		}
+	}
+	if panicked, _ := panics(func() { a.ScatterVec([]int{0}, NewVecDense(2, nil)) }); !panicked {
+		t.Error("expected scatter panic for length mismatch")
+	}
+}
+
+func TestVecDenseQuantile(t *testing.T) {
+	t.Parallel()
+	for k, test := range []struct {
+		v    *VecDense
+		p    float64
+		want float64
+	}{
+		{v: NewVecDense(1, []float64{3}), p: 0, want: 3},
+		{v: NewVecDense(1, []float64{3}), p: 0.7, want: 3},
+		{v: NewVecDense(1, []float64{3}), p: 1, want: 3},
+		{v: NewVecDense(4, []float64{4, 1, 3, 2}), p: 0, want: 1},
+		{v: NewVecDense(4, []float64{4, 1, 3, 2}), p: 1, want: 4},
+		{v: NewVecDense(4, []float64{4, 1, 3, 2}), p: 0.5, want: 2.5},
+		{v: NewVecDense(4, []float64{4, 1, 3, 2}), p: 0.25, want: 1.75},
+		{v: NewVecDense(5, []float64{5, 1, 4, 2, 3}), p: 0.5, want: 3},
+		{v: NewDense(3, 2, []float64{3, 0, 1, 0, 2, 0}).ColView(0).(*VecDense), p: 0.5, want: 2},
+	} {
+		orig := NewVecDense(test.v.Len(), nil)
+		orig.CopyVec(test.v)
+		got := test.v.Quantile(test.p)
+		if got != test.want {
+			t.Errorf("test %d: unexpected quantile: got: %v want: %v", k, got, test.want)
+		}
+		if !Equal(test.v, orig) {
+			t.Errorf("test %d: receiver modified", k)
+		}
+	}
+
+	v := NewVecDense(6, []float64{6, 1, 5, 2, 4, 3})
+	if got := v.Median(); got != 3.5 {
+		t.Errorf("unexpected median: got: %v want: 3.5", got)
+	}
+
+	for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+		if panicked, _ := panics(func() { v.Quantile(p) }); !panicked {
+			t.Errorf("expected panic for p=%v", p)
+		}
+	}
+	if panicked, _ := panics(func() { new(VecDense).Median() }); !panicked {
+		t.Error("expected panic for empty receiver")
+	}
+}
+
+func TestVecDenseArgsort(t *testing.T) {
+	t.Parallel()
+	for k, test := range []struct {
+		v    *VecDense
+		want []int
+	}{
+		{v: NewVecDense(1, []float64{3}), want: []int{0}},
+		{v: NewVecDense(4, []float64{4, 1, 3, 2}), want: []int{1, 3, 2, 0}},
+		{v: NewVecDense(5, []float64{2, 1, 2, 1, 0}), want: []int{4, 1, 3, 0, 2}},
+		{v: NewVecDense(3, []float64{1, math.NaN(), 0}), want: []int{1, 2, 0}},
+		{v: NewDense(3, 2, []float64{3, 0, 1, 0, 2, 0}).ColView(0).(*VecDense), want: []int{1, 2, 0}},
+		{v: &VecDense{}, want: []int{}},
+	} {
+		got := test.v.Argsort()
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: unexpected permutation: got: %v want: %v", k, got, test.want)
+		}
+	}
+}
+
+func TestVecDenseTopK(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		v    *VecDense
+		k    int
+		want []int
+	}{
+		{v: NewVecDense(1, []float64{3}), k: 1, want: []int{0}},
+		{v: NewVecDense(4, []float64{4, 1, 3, 2}), k: 0, want: []int{}},
+		{v: NewVecDense(4, []float64{4, 1, 3, 2}), k: 2, want: []int{0, 2}},
+		{v: NewVecDense(5, []float64{2, 1, 2, 1, 0}), k: 3, want: []int{0, 2, 1}},
+		{v: NewVecDense(4, []float64{1, math.NaN(), 0, math.NaN()}), k: 4, want: []int{0, 2, 1, 3}},
+		{v: NewDense(3, 2, []float64{3, 0, 1, 0, 2, 0}).ColView(0).(*VecDense), k: 2, want: []int{0, 2}},
+	} {
+		got := test.v.TopK(test.k)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: unexpected indices: got: %v want: %v", i, got, test.want)
+		}
+	}
+
+	// Compare with a full stable sort on vectors with many ties.
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 10, 100} {
+		v := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			v.SetVec(i, float64(rnd.Intn(n/3+1)))
+		}
+		p := make([]int, n)
+		for i := range p {
+			p[i] = i
+		}
+		sort.SliceStable(p, func(i, j int) bool { return v.AtVec(p[i]) > v.AtVec(p[j]) })
+		for _, k := range []int{0, 1, n / 2, n} {
+			got := v.TopK(k)
+			if !reflect.DeepEqual(got, p[:k]) {
+				t.Errorf("unexpected indices for n=%d k=%d: got: %v want: %v", n, k, got, p[:k])
+			}
+		}
+	}
+
+	v := NewVecDense(3, nil)
+	for _, k := range []int{-1, 4} {
+		panicked, message := panics(func() { v.TopK(k) })
+		if !panicked || message != ErrShape.Error() {
+			t.Errorf("expected ErrShape for k=%d, got %q", k, message)
+		}
+	}
+}
+
+func TestVecDenseRowColSumOf(t *testing.T) {
+	t.Parallel()
+	a := NewDense(3, 4, []float64{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	})
+	rowSums := []float64{10, 26, 42}
+	colSums := []float64{15, 18, 21, 24}
+	for k, test := range []struct {
+		a          Matrix
+		rows, cols []float64
+	}{
+		{a: a, rows: rowSums, cols: colSums},
+		{a: a.T(), rows: colSums, cols: rowSums},
+		{a: asBasicMatrix(a), rows: rowSums, cols: colSums},
+		{a: a.Slice(1, 3, 1, 3), rows: []float64{13, 21}, cols: []float64{16, 18}},
+	} {
+		var rows, cols VecDense
+		rows.RowSumOf(test.a)
+		cols.ColSumOf(test.a)
+		if want := NewVecDense(len(test.rows), test.rows); !Equal(&rows, want) {
+			t.Errorf("test %d: unexpected row sums: got: %v want: %v", k, rows.RawVector().Data, test.rows)
+		}
+		if want := NewVecDense(len(test.cols), test.cols); !Equal(&cols, want) {
+			t.Errorf("test %d: unexpected column sums: got: %v want: %v", k, cols.RawVector().Data, test.cols)
+		}
+	}
+
+	// Strided receiver.
+	dst := NewDense(4, 2, nil)
+	v := dst.ColView(1).(*VecDense)
+	v.ColSumOf(a)
+	if want := NewVecDense(4, colSums); !Equal(v, want) {
+		t.Errorf("unexpected column sums into strided receiver: got: %v want: %v", Formatted(v.T()), colSums)
+	}
+
+	if panicked, _ := panics(func() { NewVecDense(2, nil).RowSumOf(a) }); !panicked {
+		t.Error("expected panic for receiver length mismatch")
+	}
+}
+
+func TestVecDenseCentroidOf(t *testing.T) {
+	t.Parallel()
+	x := NewDense(3, 2, []float64{
+		1, 2,
+		3, 6,
+		-1, 4,
+	})
+	for _, test := range []struct {
+		x       Matrix
+		weights *VecDense
+		want    []float64
+	}{
+		{x: x, want: []float64{1, 4}},
+		{x: x, weights: NewVecDense(3, []float64{1, 1, 2}), want: []float64{0.5, 4}},
+		{x: x, weights: NewVecDense(3, []float64{0, 3, 0}), want: []float64{3, 6}},
+		{x: asBasicMatrix(x), weights: NewVecDense(3, []float64{1, 1, 2}), want: []float64{0.5, 4}},
+		{x: x.T(), weights: NewVecDense(2, []float64{3, 1}), want: []float64{1.25, 3.75, 0.25}},
+		{x: x.Slice(1, 3, 0, 2), weights: makeVecDenseInc(3, []float64{1, 3}), want: []float64{0, 4.5}},
+	} {
+		var v VecDense
+		v.CentroidOf(test.x, test.weights)
+		want := NewVecDense(len(test.want), test.want)
+		if !EqualApprox(&v, want, 1e-15) {
+			t.Errorf("unexpected centroid of %T with weights %v: got %v want %v", test.x, test.weights, Formatted(v.T()), test.want)
+		}
+	}
+
+	// Strided receiver.
+	v := makeVecDenseInc(2, []float64{7, 7})
+	v.CentroidOf(x, nil)
+	if !Equal(v, NewVecDense(2, []float64{1, 4})) {
+		t.Errorf("unexpected centroid into strided receiver: got %v", Formatted(v.T()))
+	}
+
+	panicked, message := panics(func() { new(VecDense).CentroidOf(x, NewVecDense(3, []float64{1, -1, 0})) })
+	if !panicked || message != "mat: zero total weight" {
+		t.Errorf("expected panic for zero total weight, got %q", message)
+	}
+	panicked, message = panics(func() { new(VecDense).CentroidOf(x, NewVecDense(2, nil)) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched weights, got %q", message)
+	}
+}
+
+func TestVecDenseRowColNormOf(t *testing.T) {
+	t.Parallel()
+	a := NewDense(3, 4, []float64{
+		1, -2, 3, 4,
+		0, 0, 0, 0,
+		-9, 10, -11, 12,
+	})
+	for _, norm := range []float64{1, 2, math.Inf(1)} {
+		for k, m := range []Matrix{a, a.T(), asBasicMatrix(a), a.Slice(1, 3, 1, 3)} {
+			r, c := m.Dims()
+			wantRows := NewVecDense(r, nil)
+			for i := 0; i < r; i++ {
+				wantRows.SetVec(i, Norm(NewVecDense(c, Row(nil, i, m)), norm))
+			}
+			wantCols := NewVecDense(c, nil)
+			for j := 0; j < c; j++ {
+				wantCols.SetVec(j, Norm(NewVecDense(r, Col(nil, j, m)), norm))
+			}
+
+			var rows, cols VecDense
+			rows.RowNormOf(m, norm)
+			cols.ColNormOf(m, norm)
+			if !EqualApprox(&rows, wantRows, 1e-14) {
+				t.Errorf("test %d norm=%v: unexpected row norms: got: %v want: %v", k, norm, Formatted(rows.T()), Formatted(wantRows.T()))
+			}
+			if !EqualApprox(&cols, wantCols, 1e-14) {
+				t.Errorf("test %d norm=%v: unexpected column norms: got: %v want: %v", k, norm, Formatted(cols.T()), Formatted(wantCols.T()))
+			}
+		}
+	}
+
+	// Strided receiver.
+	dst := NewDense(4, 2, nil)
+	v := dst.ColView(1).(*VecDense)
+	v.ColNormOf(a, 1)
+	if want := NewVecDense(4, []float64{10, 12, 14, 16}); !Equal(v, want) {
+		t.Errorf("unexpected column norms into strided receiver: got: %v want: %v", Formatted(v.T()), Formatted(want.T()))
+	}
+
+	if panicked, _ := panics(func() { NewVecDense(2, nil).RowNormOf(a, 2) }); !panicked {
+		t.Error("expected panic for receiver length mismatch")
+	}
+	panicked, message := panics(func() { new(VecDense).ColNormOf(a, 3) })
+	if !panicked || message != ErrNormOrder.Error() {
+		t.Errorf("expected ErrNormOrder, got %q", message)
+	}
+}
+
+func TestVecDenseLogSumExp(t *testing.T) {
+	t.Parallel()
+	for k, test := range []struct {
+		v    *VecDense
+		want float64
+	}{
+		{v: NewVecDense(1, []float64{2}), want: 2},
+		{v: NewVecDense(3, []float64{0, 0, 0}), want: math.Log(3)},
+		{v: NewVecDense(2, []float64{1000, 1000}), want: 1000 + math.Log(2)},
+		{v: NewVecDense(2, []float64{-1000, -1000}), want: -1000 + math.Log(2)},
+		{v: NewVecDense(3, []float64{1, 2, 3}), want: math.Log(math.Exp(1) + math.Exp(2) + math.Exp(3))},
+		{v: NewVecDense(2, []float64{math.Inf(-1), math.Inf(-1)}), want: math.Inf(-1)},
+		{v: NewVecDense(2, []float64{math.Inf(-1), 0}), want: 0},
+		{v: NewVecDense(2, []float64{math.Inf(1), 0}), want: math.Inf(1)},
+		{v: NewDense(3, 2, []float64{0, 100, 0, 100, 0, 100}).ColView(0).(*VecDense), want: math.Log(3)},
+	} {
+		got := test.v.LogSumExp()
+		if !floats.EqualWithinAbsOrRel(got, test.want, 1e-14, 1e-14) {
+			t.Errorf("test %d: unexpected result: got: %v want: %v", k, got, test.want)
+		}
+	}
+	if got := NewVecDense(2, []float64{math.NaN(), 0}).LogSumExp(); !math.IsNaN(got) {
+		t.Errorf("unexpected result for NaN input: got: %v want: NaN", got)
+	}
+	if panicked, _ := panics(func() { new(VecDense).LogSumExp() }); !panicked {
+		t.Error("expected panic for empty receiver")
+	}
+}
+
+func TestVecDenseRepeatVec(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a        Vector
+		count    int
+		want     []float64
+		wantEach []float64
+	}{
+		{
+			a:        NewVecDense(1, []float64{7}),
+			count:    3,
+			want:     []float64{7, 7, 7},
+			wantEach: []float64{7, 7, 7},
+		},
+		{
+			a:        NewVecDense(3, []float64{1, 2, 3}),
+			count:    1,
+			want:     []float64{1, 2, 3},
+			wantEach: []float64{1, 2, 3},
+		},
+		{
+			a:        NewVecDense(2, []float64{1, 2}),
+			count:    3,
+			want:     []float64{1, 2, 1, 2, 1, 2},
+			wantEach: []float64{1, 1, 1, 2, 2, 2},
+		},
+		{
+			a:        NewDense(2, 2, []float64{1, 0, 2, 0}).ColView(0),
+			count:    2,
+			want:     []float64{1, 2, 1, 2},
+			wantEach: []float64{1, 1, 2, 2},
+		},
+		{
+			a:        &basicVector{m: []float64{3, 4}},
+			count:    2,
+			want:     []float64{3, 4, 3, 4},
+			wantEach: []float64{3, 3, 4, 4},
+		},
+	} {
+		var v, each VecDense
+		v.RepeatVec(test.a, test.count)
+		each.RepeatEachVec(test.a, test.count)
+		if want := NewVecDense(len(test.want), test.want); !Equal(&v, want) {
+			t.Errorf("test %d: unexpected RepeatVec result: got: %v want: %v", i, v.RawData(), test.want)
+		}
+		if want := NewVecDense(len(test.wantEach), test.wantEach); !Equal(&each, want) {
+			t.Errorf("test %d: unexpected RepeatEachVec result: got: %v want: %v", i, each.RawData(), test.wantEach)
+		}
+	}
+
+	// Strided receiver.
+	v := NewDense(4, 2, nil).ColView(1).(*VecDense)
+	v.RepeatVec(NewVecDense(2, []float64{1, 2}), 2)
+	if want := NewVecDense(4, []float64{1, 2, 1, 2}); !Equal(v, want) {
+		t.Errorf("unexpected RepeatVec result for strided receiver: got: %v", v.RawData())
+	}
+
+	a := NewVecDense(2, nil)
+	for _, count := range []int{-1, 0} {
+		if panicked, _ := panics(func() { new(VecDense).RepeatVec(a, count) }); !panicked {
+			t.Errorf("expected RepeatVec panic for count %d", count)
+		}
+		if panicked, _ := panics(func() { new(VecDense).RepeatEachVec(a, count) }); !panicked {
+			t.Errorf("expected RepeatEachVec panic for count %d", count)
+		}
+	}
+}
+
+func TestVecDenseHistogram(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		v        *VecDense
+		dst      []float64
+		bins     int
+		min, max float64
+		clamp    bool
+		want     []float64
+	}{
+		{
+			v:    NewVecDense(5, []float64{0, 0.5, 1, 1.5, 2}),
+			bins: 2, min: 0, max: 2,
+			want: []float64{2, 3},
+		},
+		{
+			v:    NewVecDense(6, []float64{-1, 0, 1, 2, 3, math.NaN()}),
+			bins: 4, min: 0, max: 2,
+			want: []float64{1, 0, 1, 1},
+		},
+		{
+			v:    NewVecDense(6, []float64{-1, 0, 1, 2, 3, math.NaN()}),
+			bins: 4, min: 0, max: 2, clamp: true,
+			want: []float64{2, 0, 1, 2},
+		},
+		{
+			v:    NewVecDense(4, []float64{3, 1, 2, 5}),
+			bins: 2,
+			want: []float64{2, 2},
+		},
+		{
+			v:    NewVecDense(3, []float64{4, 4, 4}),
+			bins: 3,
+			want: []float64{3, 0, 0},
+		},
+		{
+			v:    NewDense(3, 2, []float64{0.1, 9, 0.2, 9, 0.9, 9}).ColView(0).(*VecDense),
+			dst:  []float64{-1},
+			bins: 2, min: 0, max: 1,
+			want: []float64{-1, 2, 1},
+		},
+		{
+			v:    NewVecDense(3, []float64{math.Inf(-1), 0, 1}),
+			bins: 4,
+			want: []float64{1, 0, 0, 1},
+		},
+		{
+			v:    NewVecDense(6, []float64{math.Inf(-1), 0, 1, 2, math.Inf(1), math.NaN()}),
+			bins: 2, clamp: true,
+			want: []float64{2, 3},
+		},
+		{
+			v:    NewVecDense(3, []float64{math.Inf(1), math.NaN(), math.Inf(-1)}),
+			bins: 2,
+			want: []float64{0, 0},
+		},
+		{
+			v:    NewVecDense(3, []float64{math.Inf(1), math.NaN(), math.Inf(-1)}),
+			bins: 2, clamp: true,
+			want: []float64{2, 0},
+		},
+		{
+			v:    NewVecDense(3, []float64{-1e308, 0, 1e308}),
+			bins: 2,
+			want: []float64{1, 2},
+		},
+		{
+			v:    NewVecDense(4, []float64{math.Inf(-1), -1, 1, math.Inf(1)}),
+			bins: 2, min: math.Inf(-1), max: math.Inf(1),
+			want: []float64{3, 1},
+		},
+	} {
+		got := test.v.Histogram(test.dst, test.bins, test.min, test.max, test.clamp)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: unexpected histogram: got: %v want: %v", i, got, test.want)
+		}
+	}
+
+	v := NewVecDense(2, nil)
+	if panicked, _ := panics(func() { v.Histogram(nil, 0, 0, 1, false) }); !panicked {
+		t.Error("expected panic for zero bins")
+	}
+	if panicked, _ := panics(func() { v.Histogram(nil, 1, 1, 0, false) }); !panicked {
+		t.Error("expected panic for inverted range")
+	}
+}
+
+func TestVecDenseAddScaled(t *testing.T) {
+	t.Parallel()
+	for _, alpha := range []float64{0, 1, -1, 2.3, -2.3} {
+		method := func(receiver, a, b Matrix) {
+			type addScaledVecer interface {
+				AddScaledVec(Vector, float64, Vector)
+			}
+			v := receiver.(addScaledVecer)
+			v.AddScaledVec(a.(Vector), alpha, b.(Vector))
+		}
+		denseComparison := func(receiver, a, b *Dense) {
+			var sb Dense
+			sb.Scale(alpha, b)
+			receiver.Add(a, &sb)
+		}
+		testTwoInput(t, "AddScaledVec", &VecDense{}, method, denseComparison, legalTypesVectorVector, legalSizeSameVec, 1e-14)
+	}
+}
+
+func TestVecDenseAddScaledStrided(t *testing.T) {
+	t.Parallel()
+	const n = 17
+	src := rand.NewSource(1)
+	for _, incs := range [][3]int{
+		// Increments of the receiver, a and b.
+		{1, 1, 1},
+		{1, 3, 1},
+		{1, 1, 3},
+		{1, 2, 5},
+		{4, 1, 1},
+		{4, 2, 3},
+	} {
+		for _, inPlace := range []bool{false, true} {
+			a := randVecDense(n, incs[1], 1, src)
+			b := randVecDense(n, incs[2], 1, src)
+			v := randVecDense(n, incs[0], 1, src)
+			if inPlace {
+				v = a
+			}
+			const alpha = 2.3
+			want := make([]float64, n)
+			for i := range want {
+				want[i] = a.AtVec(i) + alpha*b.AtVec(i)
+			}
+			v.AddScaledVec(a, alpha, b)
+			for i, w := range want {
+				// The fast paths must agree exactly with the
+				// element-wise computation.
+				if got := v.AtVec(i); got != w {
+					t.Errorf("unexpected result at %d for incs=%v in-place=%t: got %v want %v",
+						i, incs, inPlace, got, w)
+				}
+			}
+		}
+	}
+}
+
+func TestVecDenseAddScaledVecNorm(t *testing.T) {
+	t.Parallel()
+	const n = 17
+	src := rand.NewSource(1)
+	for _, incs := range [][3]int{
+		// Increments of the receiver, a and b.
+		{1, 1, 1},
+		{1, 2, 5},
+		{4, 2, 3},
+	} {
+		for _, norm := range []float64{1, 2, math.Inf(1)} {
+			for _, alias := range []string{"none", "a", "b"} {
+				a := randVecDense(n, incs[1], 1, src)
+				b := randVecDense(n, incs[2], 1, src)
+				var v *VecDense
+				switch alias {
+				case "none":
+					v = randVecDense(n, incs[0], 1, src)
+				case "a":
+					v = a
+				case "b":
+					v = b
+				}
+				const alpha = -0.7
+				var want VecDense
+				want.AddScaledVec(a, alpha, b)
+				wantNorm := Norm(&want, norm)
+				gotNorm := v.AddScaledVecNorm(a, alpha, b, norm)
+				if !EqualApprox(v, &want, 1e-15) {
+					t.Errorf("unexpected result for incs=%v norm=%v alias=%s", incs, norm, alias)
+				}
+				if math.Abs(gotNorm-wantNorm) > 1e-14*wantNorm {
+					t.Errorf("unexpected norm for incs=%v norm=%v alias=%s: got %v want %v", incs, norm, alias, gotNorm, wantNorm)
+				}
+			}
+		}
+	}
+
+	// The 2-norm must not overflow or be affected by non-finite elements.
+	var v VecDense
+	a := NewVecDense(2, []float64{3e300, 0})
+	b := &basicVector{m: []float64{0, 1}}
+	if got, want := v.AddScaledVecNorm(a, 4e300, b, 2), 5e300; math.Abs(got-want) > 1e-15*want {
+		t.Errorf("unexpected 2-norm for large elements: got %v want %v", got, want)
+	}
+	for _, test := range []struct {
+		a    []float64
+		want float64
+	}{
+		{a: []float64{1, math.Inf(-1), math.Inf(1)}, want: math.Inf(1)},
+		{a: []float64{1, math.NaN(), 1}, want: math.NaN()},
+		{a: []float64{math.NaN(), math.Inf(1), 1}, want: math.NaN()},
+	} {
+		a := NewVecDense(len(test.a), test.a)
+		got := new(VecDense).AddScaledVecNorm(a, 1, NewVecDense(len(test.a), nil), 2)
+		if got != test.want && !(math.IsNaN(got) && math.IsNaN(test.want)) {
+			t.Errorf("unexpected 2-norm for %v: got %v want %v", test.a, got, test.want)
+		}
+	}
+
+	panicked, message := panics(func() { v.AddScaledVecNorm(NewVecDense(2, nil), 1, NewVecDense(2, nil), 3) })
+	if !panicked || message != ErrNormOrder.Error() {
+		t.Errorf("expected ErrNormOrder, got %q", message)
+	}
+	panicked, message = panics(func() { new(VecDense).AddScaledVecNorm(NewVecDense(2, nil), 1, NewVecDense(3, nil), 2) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape, got %q", message)
+	}
+}
+
+func TestVecDenseAdd(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a, b Vector
+		want *VecDense
+	}{
+		{
+			a:    NewVecDense(3, []float64{0, 1, 2}),
+			b:    NewVecDense(3, []float64{0, 2, 3}),
+			want: NewVecDense(3, []float64{0, 3, 5}),
+		},
+		{
+			a:    NewVecDense(3, []float64{0, 1, 2}),
+			b:    NewDense(3, 1, []float64{0, 2, 3}).ColView(0),
+			want: NewVecDense(3, []float64{0, 3, 5}),
+		},
+		{
+			a:    NewDense(3, 1, []float64{0, 1, 2}).ColView(0),
+			b:    NewDense(3, 1, []float64{0, 2, 3}).ColView(0),
+			want: NewVecDense(3, []float64{0, 3, 5}),
+		},
+	} {
+		var v VecDense
+		v.AddVec(test.a.(*VecDense), test.b.(*VecDense))
+		if !reflect.DeepEqual(v.RawVector(), test.want.RawVector()) {
+			t.Errorf("unexpected result for test %d: got: %v want: %v", i, v.RawVector(), test.want.RawVector())
+		}
+	}
+}
+
+func TestVecDenseSub(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a, b Vector
+		want *VecDense
+	}{
+		{
+			a:    NewVecDense(3, []float64{0, 1, 2}),
+			b:    NewVecDense(3, []float64{0, 0.5, 1}),
+			want: NewVecDense(3, []float64{0, 0.5, 1}),
+		},
+		{
+			a:    NewVecDense(3, []float64{0, 1, 2}),
+			b:    NewDense(3, 1, []float64{0, 0.5, 1}).ColView(0),
+			want: NewVecDense(3, []float64{0, 0.5, 1}),
+		},
+		{
+			a:    NewDense(3, 1, []float64{0, 1, 2}).ColView(0),
+			b:    NewDense(3, 1, []float64{0, 0.5, 1}).ColView(0),
+			want: NewVecDense(3, []float64{0, 0.5, 1}),
+		},
+	} {
+		var v VecDense
+		v.SubVec(test.a.(*VecDense), test.b.(*VecDense))
+		if !reflect.DeepEqual(v.RawVector(), test.want.RawVector()) {
+			t.Errorf("unexpected result for test %d: got: %v want: %v", i, v.RawVector(), test.want.RawVector())
+		}
+	}
+}
+
+func TestVecDenseResidualVec(t *testing.T) {
+	t.Parallel()
+	src := rand.NewSource(1)
+	rnd := rand.New(src)
+	for _, test := range []struct {
+		r, c int
+		inc  int
+	}{
+		{r: 1, c: 1, inc: 1},
+		{r: 3, c: 3, inc: 1},
+		{r: 4, c: 7, inc: 2},
+		{r: 7, c: 4, inc: 3},
+	} {
+		a := NewDense(test.r, test.c, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		x := randVecDense(test.c, test.inc, 1, src)
+		b := randVecDense(test.r, test.inc, 1, src)
+		xT := randVecDense(test.r, test.inc, 1, src)
+		bT := randVecDense(test.c, test.inc, 1, src)
+		xb := &basicVector{m: make([]float64, test.c)}
+		for i := range xb.m {
+			xb.m[i] = x.AtVec(i)
+		}
+
+		for _, in := range []struct {
+			name string
+			a    Matrix
+			x, b Vector
+		}{
+			{name: "Dense", a: a, x: x, b: b},
+			{name: "transposed Dense", a: a.T(), x: xT, b: bT},
+			{name: "basic matrix", a: asBasicMatrix(a), x: x, b: b},
+			{name: "basic vector", a: a, x: xb, b: b},
+		} {
+			var want VecDense
+			want.MulVec(in.a, in.x)
+			want.SubVec(in.b, &want)
+
+			var got VecDense
+			got.ResidualVec(in.a, in.x, in.b)
+			if !EqualApprox(&got, &want, 1e-14) {
+				t.Errorf("unexpected residual for %s %d×%d inc=%d:\ngot: %v\nwant:%v", in.name, test.r, test.c, test.inc, Formatted(got.T()), Formatted(want.T()))
+			}
+
+			// The receiver may be b.
+			bc := VecDenseCopyOf(in.b)
+			bc.ResidualVec(in.a, in.x, bc)
+			if !EqualApprox(bc, &want, 1e-14) {
+				t.Errorf("unexpected residual for %s %d×%d inc=%d with receiver b", in.name, test.r, test.c, test.inc)
+			}
+		}
+
+		if test.r == test.c {
+			// The receiver may be x.
+			var want VecDense
+			want.MulVec(a, x)
+			want.SubVec(b, &want)
+			xc := VecDenseCopyOf(x)
+			xc.ResidualVec(a, xc, b)
+			if !EqualApprox(xc, &want, 1e-14) {
+				t.Errorf("unexpected residual for %d×%d inc=%d with receiver x", test.r, test.c, test.inc)
+			}
+		}
+	}
+
+	panicked, message := panics(func() { new(VecDense).ResidualVec(NewDense(2, 3, nil), NewVecDense(2, nil), NewVecDense(2, nil)) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched x, got %q", message)
+	}
+	panicked, message = panics(func() { new(VecDense).ResidualVec(NewDense(2, 3, nil), NewVecDense(3, nil), NewVecDense(3, nil)) })
+	if !panicked || message != ErrShape.Error() {
+		t.Errorf("expected ErrShape for mismatched b, got %q", message)
+	}
+}
+
+func TestVecDenseMulElem(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a, b Vector
+		want *VecDense
+	}{
+		{
+			a:    NewVecDense(3, []float64{0, 1, 2}),
+			b:    NewVecDense(3, []float64{0, 2, 3}),
+			want: NewVecDense(3, []float64{0, 2, 6}),
+		},
+		{
+			a:    NewVecDense(3, []float64{0, 1, 2}),
+			b:    NewDense(3, 1, []float64{0, 2, 3}).ColView(0),
+			want: NewVecDense(3, []float64{0, 2, 6}),
+		},
+		{
+			a:    NewDense(3, 1, []float64{0, 1, 2}).ColView(0),
+			b:    NewDense(3, 1, []float64{0, 2, 3}).ColView(0),
+			want: NewVecDense(3, []float64{0, 2, 6}),
 		},
 	} {
 		var v VecDense
@@ -473,12 +2536,279 @@ func TestVecDenseDivElem(t *testing.T) {
 			b:    NewDense(3, 1, []float64{0.5, 0.5, 1}).ColView(0),
 			want: NewVecDense(3, []float64{1, 2, 2}),
 		},
+		{
+			a:    NewDense(3, 2, []float64{0.5, 0, 1, 0, 2, 0}).ColView(0),
+			b:    NewDense(3, 2, []float64{0, 0.5, 0, 0.5, 0, 1}).ColView(1),
+			want: NewVecDense(3, []float64{1, 2, 2}),
+		},
 	} {
 		var v VecDense
 		v.DivElemVec(test.a.(*VecDense), test.b.(*VecDense))
 		if !reflect.DeepEqual(v.RawVector(), test.want.RawVector()) {
 			t.Errorf("unexpected result for test %d: got: %v want: %v", i, v.RawVector(), test.want.RawVector())
 		}
+
+		// Check in-place division with unit and non-unit increments.
+		for _, inc := range []int{1, 3} {
+			s := makeVecDenseInc(inc, []float64{test.a.AtVec(0), test.a.AtVec(1), test.a.AtVec(2)})
+			s.DivElemVec(s, test.b)
+			if !Equal(s, test.want) {
+				t.Errorf("unexpected in-place result for test %d inc=%d: got: %v want: %v", i, inc, s.RawVector(), test.want.RawVector())
+			}
+		}
+	}
+}
+
+func TestVecDenseDiffVec(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a    Vector
+		want *VecDense
+	}{
+		{
+			a:    NewVecDense(2, []float64{1, 3}),
+			want: NewVecDense(1, []float64{2}),
+		},
+		{
+			a:    NewVecDense(4, []float64{1, 2, 4, 8}),
+			want: NewVecDense(3, []float64{1, 2, 4}),
+		},
+		{
+			a:    NewDense(4, 2, []float64{1, 0, 2, 0, 4, 0, 8, 0}).ColView(0),
+			want: NewVecDense(3, []float64{1, 2, 4}),
+		},
+		{
+			a:    &basicVector{m: []float64{5, 3, -1}},
+			want: NewVecDense(2, []float64{-2, -4}),
+		},
+	} {
+		var v VecDense
+		v.DiffVec(test.a)
+		if !Equal(&v, test.want) {
+			t.Errorf("unexpected result for test %d: got: %v want: %v", i, v.RawVector().Data, test.want.RawVector().Data)
+		}
+	}
+
+	a := NewVecDense(3, []float64{1, 2, 3})
+	if panicked, _ := panics(func() { a.DiffVec(a) }); !panicked {
+		t.Error("expected panic for aliased receiver")
+	}
+	if panicked, _ := panics(func() { new(VecDense).DiffVec(NewVecDense(1, nil)) }); !panicked {
+		t.Error("expected panic for short input")
+	}
+}
+
+func TestVecDenseWindowSumVec(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a        Vector
+		w        int
+		wantSum  []float64
+		wantMean []float64
+	}{
+		{
+			a:        NewVecDense(3, []float64{1, 2, 3}),
+			w:        1,
+			wantSum:  []float64{1, 2, 3},
+			wantMean: []float64{1, 2, 3},
+		},
+		{
+			a:        NewVecDense(3, []float64{1, 2, 3}),
+			w:        3,
+			wantSum:  []float64{6},
+			wantMean: []float64{2},
+		},
+		{
+			a:        NewVecDense(5, []float64{1, 2, 3, 4, 5}),
+			w:        2,
+			wantSum:  []float64{3, 5, 7, 9},
+			wantMean: []float64{1.5, 2.5, 3.5, 4.5},
+		},
+		{
+			a:        NewDense(4, 2, []float64{1, 0, 3, 0, 5, 0, 7, 0}).ColView(0),
+			w:        3,
+			wantSum:  []float64{9, 15},
+			wantMean: []float64{3, 5},
+		},
+		{
+			a:        &basicVector{m: []float64{2, -2, 4, 0}},
+			w:        2,
+			wantSum:  []float64{0, 2, 4},
+			wantMean: []float64{0, 1, 2},
+		},
+	} {
+		var sum, mean VecDense
+		sum.WindowSumVec(test.a, test.w)
+		mean.WindowMeanVec(test.a, test.w)
+		want := NewVecDense(len(test.wantSum), test.wantSum)
+		if !Equal(&sum, want) {
+			t.Errorf("unexpected sum for test %d: got: %v want: %v", i, sum.RawVector().Data, test.wantSum)
+		}
+		want = NewVecDense(len(test.wantMean), test.wantMean)
+		if !Equal(&mean, want) {
+			t.Errorf("unexpected mean for test %d: got: %v want: %v", i, mean.RawVector().Data, test.wantMean)
+		}
+	}
+
+	a := NewVecDense(3, []float64{1, 2, 3})
+	for _, w := range []int{-1, 0, 4} {
+		if panicked, _ := panics(func() { new(VecDense).WindowSumVec(a, w) }); !panicked {
+			t.Errorf("expected panic for window %d", w)
+		}
+	}
+}
+
+func TestVecDenseShiftVec(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		a        []float64
+		k        int
+		circular bool
+		want     []float64
+	}{
+		{a: []float64{1, 2, 3, 4}, k: 0, want: []float64{1, 2, 3, 4}},
+		{a: []float64{1, 2, 3, 4}, k: 1, want: []float64{0, 1, 2, 3}},
+		{a: []float64{1, 2, 3, 4}, k: -2, want: []float64{3, 4, 0, 0}},
+		{a: []float64{1, 2, 3, 4}, k: 4, want: []float64{0, 0, 0, 0}},
+		{a: []float64{1, 2, 3, 4}, k: -5, want: []float64{0, 0, 0, 0}},
+		{a: []float64{1, 2, 3, 4}, k: 1, circular: true, want: []float64{4, 1, 2, 3}},
+		{a: []float64{1, 2, 3, 4}, k: -1, circular: true, want: []float64{2, 3, 4, 1}},
+		{a: []float64{1, 2, 3, 4}, k: 6, circular: true, want: []float64{3, 4, 1, 2}},
+		{a: []float64{1, 2, 3, 4}, k: -8, circular: true, want: []float64{1, 2, 3, 4}},
+	} {
+		want := NewVecDense(len(test.want), test.want)
+		for _, a := range []Vector{
+			NewVecDense(len(test.a), test.a),
+			&basicVector{m: test.a},
+			makeVecDenseInc(3, test.a),
+		} {
+			var got VecDense
+			got.ShiftVec(a, test.k, test.circular)
+			if !Equal(&got, want) {
+				t.Errorf("unexpected result for test %d with %T: got: %v want: %v", i, a, got.RawVector().Data, test.want)
+			}
+		}
+
+		// In-place operation.
+		v := makeVecDenseInc(2, test.a)
+		v.ShiftVec(v, test.k, test.circular)
+		if !Equal(v, want) {
+			t.Errorf("unexpected in-place result for test %d: got: %v want: %v", i, Formatted(v.T()), test.want)
+		}
+	}
+
+	for _, circular := range []bool{false, true} {
+		for _, k := range []int{0, 1} {
+			var v VecDense
+			if panicked, msg := panics(func() { v.ShiftVec(&v, k, circular) }); !panicked || msg != ErrZeroLength.Error() {
+				t.Errorf("expected ErrZeroLength panic for empty in-place shift k=%d circular=%t, got: %q", k, circular, msg)
+			}
+			if panicked, msg := panics(func() { new(VecDense).ShiftVec(&v, k, circular) }); !panicked || msg != ErrZeroLength.Error() {
+				t.Errorf("expected ErrZeroLength panic for empty shift k=%d circular=%t, got: %q", k, circular, msg)
+			}
+		}
+	}
+}
+
+func TestVecDenseCumMaxMinVec(t *testing.T) {
+	t.Parallel()
+	nan := math.NaN()
+	for i, test := range []struct {
+		a       []float64
+		wantMax []float64
+		wantMin []float64
+	}{
+		{
+			a:       []float64{2},
+			wantMax: []float64{2},
+			wantMin: []float64{2},
+		},
+		{
+			a:       []float64{3, 1, 4, 1, 5, 9, 2, 6},
+			wantMax: []float64{3, 3, 4, 4, 5, 9, 9, 9},
+			wantMin: []float64{3, 1, 1, 1, 1, 1, 1, 1},
+		},
+		{
+			a:       []float64{1, 2, nan, 0, 3},
+			wantMax: []float64{1, 2, nan, nan, nan},
+			wantMin: []float64{1, 1, nan, nan, nan},
+		},
+	} {
+		wantMax := NewVecDense(len(test.wantMax), test.wantMax)
+		wantMin := NewVecDense(len(test.wantMin), test.wantMin)
+		for _, a := range []Vector{
+			NewVecDense(len(test.a), test.a),
+			&basicVector{m: test.a},
+			makeVecDenseInc(2, test.a),
+		} {
+			var gotMax, gotMin VecDense
+			gotMax.CumMaxVec(a)
+			gotMin.CumMinVec(a)
+			if !sameNaNs(&gotMax, wantMax) {
+				t.Errorf("unexpected CumMaxVec for test %d with %T: got: %v want: %v", i, a, gotMax.RawVector().Data, test.wantMax)
+			}
+			if !sameNaNs(&gotMin, wantMin) {
+				t.Errorf("unexpected CumMinVec for test %d with %T: got: %v want: %v", i, a, gotMin.RawVector().Data, test.wantMin)
+			}
+		}
+
+		// In-place operation.
+		v := makeVecDenseInc(3, test.a)
+		v.CumMaxVec(v)
+		if !sameNaNs(v, wantMax) {
+			t.Errorf("unexpected in-place CumMaxVec for test %d: got: %v want: %v", i, Formatted(v.T()), test.wantMax)
+		}
+	}
+}
+
+// sameNaNs returns whether a and b have the same length and are element-wise
+// equal, treating NaN values as equal to each other.
+func sameNaNs(a, b Vector) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		x, y := a.AtVec(i), b.AtVec(i)
+		if x != y && !(math.IsNaN(x) && math.IsNaN(y)) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestVecDenseAsDiagDense(t *testing.T) {
+	t.Parallel()
+	for i, v := range []*VecDense{
+		NewVecDense(3, []float64{1, -2, 3}),
+		NewDense(3, 2, []float64{1, 0, -2, 0, 3, 0}).ColView(0).(*VecDense),
+	} {
+		d := v.AsDiagDense()
+		want := NewDense(3, 3, []float64{
+			1, 0, 0,
+			0, -2, 0,
+			0, 0, 3,
+		})
+		if !Equal(d, want) {
+			t.Errorf("test %d: unexpected diagonal:\ngot:\n%v\nwant:\n%v", i, Formatted(d), Formatted(want))
+		}
+		if tr := d.Trace(); tr != 2 {
+			t.Errorf("test %d: unexpected trace: got: %v want: 2", i, tr)
+		}
+
+		d.SetDiag(1, 5)
+		if v.AtVec(1) != 5 {
+			t.Errorf("test %d: diagonal does not share data with vector", i)
+		}
+
+		var m Dense
+		m.Mul(d, NewDense(3, 1, []float64{1, 1, 1}))
+		if got := NewVecDense(3, []float64{1, 5, 3}); !Equal(&m, got) {
+			t.Errorf("test %d: unexpected product: got: %v want: %v", i, Formatted(&m), Formatted(got))
+		}
+	}
+
+	if panicked, _ := panics(func() { new(VecDense).AsDiagDense() }); !panicked {
+		t.Error("expected panic for empty vector")
 	}
 }
 
@@ -497,6 +2827,37 @@ func BenchmarkAddScaledVec100Inc20(b *testing.B)    { addScaledVecBench(b, 100,
 func BenchmarkAddScaledVec1000Inc20(b *testing.B)   { addScaledVecBench(b, 1000, 20) }
 func BenchmarkAddScaledVec10000Inc20(b *testing.B)  { addScaledVecBench(b, 10000, 20) }
 func BenchmarkAddScaledVec100000Inc20(b *testing.B) { addScaledVecBench(b, 100000, 20) }
+func BenchmarkAddScaledVec1000StridedOperands(b *testing.B) {
+	addScaledVecMixedBench(b, 1000, 1, 20, 20)
+}
+func BenchmarkAddScaledVec100000StridedOperands(b *testing.B) {
+	addScaledVecMixedBench(b, 100000, 1, 20, 20)
+}
+func BenchmarkAddScaledVec1000StridedB(b *testing.B)   { addScaledVecMixedBench(b, 1000, 1, 1, 20) }
+func BenchmarkAddScaledVec100000StridedB(b *testing.B) { addScaledVecMixedBench(b, 100000, 1, 1, 20) }
+func BenchmarkAddScaledVec1000StridedInPlace(b *testing.B) {
+	addScaledVecMixedBench(b, 1000, 20, 0, 20)
+}
+func BenchmarkAddScaledVec100000StridedInPlace(b *testing.B) {
+	addScaledVecMixedBench(b, 100000, 20, 0, 20)
+}
+
+// addScaledVecMixedBench benchmarks v.AddScaledVec(a, 2, x) where v, a and x
+// have the given increments. If aInc is zero, the receiver is used as a.
+func addScaledVecMixedBench(b *testing.B, size, vInc, aInc, xInc int) {
+	src := rand.NewSource(1)
+	v := randVecDense(size, vInc, 1, src)
+	a := v
+	if aInc != 0 {
+		a = randVecDense(size, aInc, 1, src)
+	}
+	x := randVecDense(size, xInc, 1, src)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.AddScaledVec(a, 2, x)
+	}
+}
+
 func addScaledVecBench(b *testing.B, size, inc int) {
 	src := rand.NewSource(1)
 	x := randVecDense(size, inc, 1, src)
@@ -585,6 +2946,32 @@ func subVecBench(b *testing.B, size, inc int) {
 	}
 }
 
+func BenchmarkMulElemVec1000000Inc1(b *testing.B) { mulElemVecBench(b, 1000000, 1) }
+func BenchmarkMulElemVec1000000Inc2(b *testing.B) { mulElemVecBench(b, 1000000, 2) }
+func mulElemVecBench(b *testing.B, size, inc int) {
+	src := rand.NewSource(1)
+	x := randVecDense(size, inc, 1, src)
+	y := randVecDense(size, inc, 1, src)
+	b.ResetTimer()
+	var v VecDense
+	for i := 0; i < b.N; i++ {
+		v.MulElemVec(x, y)
+	}
+}
+
+func BenchmarkDivElemVec1000000Inc1(b *testing.B) { divElemVecBench(b, 1000000, 1) }
+func BenchmarkDivElemVec1000000Inc2(b *testing.B) { divElemVecBench(b, 1000000, 2) }
+func divElemVecBench(b *testing.B, size, inc int) {
+	src := rand.NewSource(1)
+	x := randVecDense(size, inc, 1, src)
+	y := randVecDense(size, inc, 1, src)
+	b.ResetTimer()
+	var v VecDense
+	for i := 0; i < b.N; i++ {
+		v.DivElemVec(x, y)
+	}
+}
+
 func randVecDense(size, inc int, rho float64, src rand.Source) *VecDense {
 	if size <= 0 {
 		panic("bad vector size")
@@ -617,3 +3004,34 @@ func vectorSumBench(b *testing.B, size int) {
 		vectorSumForBench = Sum(a)
 	}
 }
+
+func BenchmarkMulVecBasic100(b *testing.B)  { mulVecBasicBench(b, 100) }
+func BenchmarkMulVecBasic1000(b *testing.B) { mulVecBasicBench(b, 1000) }
+func BenchmarkMulVecBasic2000(b *testing.B) { mulVecBasicBench(b, 2000) }
+func mulVecBasicBench(b *testing.B, size int) {
+	src := rand.NewSource(1)
+	a, _ := randDense(size, 1, src)
+	x := randVecDense(size, 1, 1, src)
+	am := asBasicMatrix(a)
+	xv := &basicVector{m: x.mat.Data}
+	b.ResetTimer()
+	var v VecDense
+	for i := 0; i < b.N; i++ {
+		v.MulVec(am, xv)
+	}
+}
+
+func BenchmarkMulVecParallel1000(b *testing.B)  { mulVecParallelBench(b, 1000, 0) }
+func BenchmarkMulVecParallel10000(b *testing.B) { mulVecParallelBench(b, 10000, 0) }
+func BenchmarkMulVecSerial1000(b *testing.B)    { mulVecParallelBench(b, 1000, 1) }
+func BenchmarkMulVecSerial10000(b *testing.B)   { mulVecParallelBench(b, 10000, 1) }
+func mulVecParallelBench(b *testing.B, size, workers int) {
+	src := rand.NewSource(1)
+	a, _ := randDense(size, 1, src)
+	x := randVecDense(size, 1, 1, src)
+	b.ResetTimer()
+	var v VecDense
+	for i := 0; i < b.N; i++ {
+		v.MulVecParallel(a, x, workers)
+	}
+}